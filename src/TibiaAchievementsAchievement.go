@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+// The base includes two levels: Achievement and Information
+type AchievementResponse struct {
+	Achievement OverviewAchievement `json:"achievement"`
+	Information Information         `json:"information"`
+}
+
+func TibiaAchievementsAchievementImpl(achievement string, BoxContentHTML string, url string) (AchievementResponse, error) {
+	// Loading HTML data into ReaderHTML for goquery with NewReader
+	ReaderHTML, err := goquery.NewDocumentFromReader(strings.NewReader(BoxContentHTML))
+	if err != nil {
+		return AchievementResponse{}, fmt.Errorf("[error] TibiaAchievementsAchievementImpl failed at goquery.NewDocumentFromReader, err: %s", err)
+	}
+
+	// the library has no page per achievement, so the list is searched
+	for _, AchievementData := range tibiaAchievementsParseList(ReaderHTML) {
+		if !strings.EqualFold(AchievementData.Name, achievement) {
+			continue
+		}
+
+		//
+		// Build the data-blob
+		return AchievementResponse{
+			AchievementData,
+			Information{
+				APIDetails: TibiaDataAPIDetails,
+				Timestamp:  TibiaDataDatetime(""),
+				TibiaURLs:  []string{url},
+				Status: Status{
+					HTTPCode: http.StatusOK,
+				},
+			},
+		}, nil
+	}
+
+	return AchievementResponse{}, validation.ErrorAchievementNotFound
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/static"
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+func TestAchievementCheesyPriest(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/achievements/achievements.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	achievementJson, err := TibiaAchievementsAchievementImpl("cheesy priest", string(data), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	achievement := achievementJson.Achievement
+
+	assert.Equal("Cheesy Priest", achievement.Name)
+	assert.Equal("You've tried to make your way into the priesthood with a little bit of cheese.", achievement.Description)
+	assert.Equal(1, achievement.Grade)
+	assert.Equal(1, achievement.Points)
+	assert.False(achievement.Premium)
+	assert.True(achievement.Secret)
+
+	_, err = TibiaAchievementsAchievementImpl("Rat Slayer of Doom", string(data), "")
+	assert.Equal(validation.ErrorAchievementNotFound, err)
+}
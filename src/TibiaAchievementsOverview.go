@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Child of AchievementsContainer
+type OverviewAchievement struct {
+	Name        string `json:"name"`        // The name of the achievement.
+	Description string `json:"description"` // The description of the achievement.
+	Grade       int    `json:"grade"`       // The grade/stars of the achievement.
+	Points      int    `json:"points"`      // The achievement points the achievement gives.
+	Premium     bool   `json:"premium"`     // Whether it requires a premium account or not.
+	Secret      bool   `json:"secret"`      // Whether it is a secret achievement or not.
+}
+
+// Child of JSONData
+type AchievementsContainer struct {
+	Achievements []OverviewAchievement `json:"achievement_list"` // The list of achievements.
+	TotalPoints  int                   `json:"total_points"`     // The achievement points of all achievements combined.
+}
+
+// The base includes two levels: Achievements and Information
+type AchievementsOverviewResponse struct {
+	Achievements AchievementsContainer `json:"achievements"`
+	Information  Information           `json:"information"`
+}
+
+func TibiaAchievementsOverviewImpl(BoxContentHTML string, url string) (AchievementsOverviewResponse, error) {
+	// Loading HTML data into ReaderHTML for goquery with NewReader
+	ReaderHTML, err := goquery.NewDocumentFromReader(strings.NewReader(BoxContentHTML))
+	if err != nil {
+		return AchievementsOverviewResponse{}, fmt.Errorf("[error] TibiaAchievementsOverviewImpl failed at goquery.NewDocumentFromReader, err: %s", err)
+	}
+
+	AchievementsData := tibiaAchievementsParseList(ReaderHTML)
+
+	var TotalPoints int
+	for _, achievement := range AchievementsData {
+		TotalPoints += achievement.Points
+	}
+
+	//
+	// Build the data-blob
+	return AchievementsOverviewResponse{
+		AchievementsContainer{
+			Achievements: AchievementsData,
+			TotalPoints:  TotalPoints,
+		},
+		Information{
+			APIDetails: TibiaDataAPIDetails,
+			Timestamp:  TibiaDataDatetime(""),
+			TibiaURLs:  []string{url},
+			Status: Status{
+				HTTPCode: http.StatusOK,
+			},
+		},
+	}, nil
+}
+
+// tibiaAchievementsParseList func - parses all achievements of the achievements library
+func tibiaAchievementsParseList(ReaderHTML *goquery.Document) []OverviewAchievement {
+	var AchievementsData []OverviewAchievement
+
+	// Running query over each row of the achievements table
+	ReaderHTML.Find(".TableContentContainer .TableContent tr").Not(".LabelH").Each(func(index int, s *goquery.Selection) {
+		columns := s.Find("td")
+		if columns.Length() < 5 {
+			return
+		}
+
+		GradeHTML, _ := columns.Eq(0).Html()
+		NameHTML, _ := columns.Eq(2).Html()
+
+		AchievementsData = append(AchievementsData, OverviewAchievement{
+			Name:        strings.TrimSpace(TibiaDataSanitizeStrings(columns.Eq(2).Text())),
+			Description: strings.TrimSpace(TibiaDataSanitizeStrings(columns.Eq(3).Text())),
+			Grade:       strings.Count(GradeHTML, "achievement-grade-symbol"),
+			Points:      TibiaDataStringToInteger(strings.TrimSpace(columns.Eq(1).Text())),
+			Premium:     strings.TrimSpace(columns.Eq(4).Text()) == "yes",
+			Secret:      strings.Contains(NameHTML, "achievement-secret-symbol"),
+		})
+	})
+
+	return AchievementsData
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/static"
+)
+
+func TestAchievementsOverview(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/achievements/achievements.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	achievementsJson, err := TibiaAchievementsOverviewImpl(string(data), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	achievements := achievementsJson.Achievements
+
+	assert.Equal(6, len(achievements.Achievements))
+	assert.Equal(26, achievements.TotalPoints)
+
+	allowanceCollector := achievements.Achievements[0]
+	assert.Equal("Allowance Collector", allowanceCollector.Name)
+	assert.Equal("You certainly have your ways when it comes to acquiring money. Many of them are pink and paved with broken fragments of porcelain.", allowanceCollector.Description)
+	assert.Equal(1, allowanceCollector.Grade)
+	assert.Equal(1, allowanceCollector.Points)
+	assert.True(allowanceCollector.Premium)
+	assert.True(allowanceCollector.Secret)
+
+	annihilator := achievements.Achievements[1]
+	assert.Equal("Annihilator", annihilator.Name)
+	assert.Equal(3, annihilator.Grade)
+	assert.Equal(8, annihilator.Points)
+	assert.True(annihilator.Premium)
+	assert.False(annihilator.Secret)
+
+	bearerOfTheRing := achievements.Achievements[2]
+	assert.Equal("Bearer of the Ring", bearerOfTheRing.Name)
+	assert.False(bearerOfTheRing.Premium)
+	assert.False(bearerOfTheRing.Secret)
+
+	boneBrother := achievements.Achievements[4]
+	assert.Equal("Bone Brother", boneBrother.Name)
+	assert.Equal(4, boneBrother.Grade)
+	assert.Equal(10, boneBrother.Points)
+}
+
+func TestAchievementsOverviewLive(t *testing.T) {
+	url := "https://www.tibia.com/library/?subtopic=achievements"
+	data := tibiaDataLivePage(t, url, "achievements/achievements.html")
+
+	achievementsJson, err := TibiaAchievementsOverviewImpl(data, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	achievements := achievementsJson.Achievements
+
+	// tibia.com has hundreds of achievements
+	assert.Greater(len(achievements.Achievements), 100)
+
+	for _, achievement := range achievements.Achievements {
+		assert.NotEmpty(achievement.Name)
+		assert.NotEmpty(achievement.Description, achievement.Name)
+		assert.GreaterOrEqual(achievement.Grade, 1, achievement.Name)
+		assert.LessOrEqual(achievement.Grade, 3, achievement.Name)
+		assert.Positive(achievement.Points, achievement.Name)
+	}
+}
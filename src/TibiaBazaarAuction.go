@@ -0,0 +1,297 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+// Child of BazaarCharacterDetails
+type BazaarCharacterGeneral struct {
+	HitPoints                 int    `json:"hit_points"`                   // The character's hit points.
+	Mana                      int    `json:"mana"`                         // The character's mana.
+	Capacity                  int    `json:"capacity"`                     // The character's capacity.
+	Speed                     int    `json:"speed"`                        // The character's speed.
+	Blessings                 string `json:"blessings"`                    // The blessings the character has. (e.g. 7/8)
+	Titles                    int    `json:"titles"`                       // The number of titles the character has unlocked.
+	CreationDate              string `json:"creation_date"`                // The timestamp when the character was created.
+	Experience                int    `json:"experience"`                   // The character's experience points.
+	Gold                      int    `json:"gold"`                         // The gold the character owns.
+	AchievementPoints         int    `json:"achievement_points"`           // The total of achievement points the character has.
+	RegularWorldTransfer      string `json:"regular_world_transfer"`       // When a regular world transfer is possible.
+	CharmExpansion            bool   `json:"charm_expansion"`              // Whether the character has the charm expansion.
+	AvailableCharmPoints      int    `json:"available_charm_points"`       // The charm points not spent yet.
+	SpentCharmPoints          int    `json:"spent_charm_points"`           // The charm points already spent.
+	DailyRewardStreak         int    `json:"daily_reward_streak"`          // The current daily reward streak.
+	HuntingTaskPoints         int    `json:"hunting_task_points"`          // The character's hunting task points.
+	PermanentHuntingTaskSlots int    `json:"permanent_hunting_task_slots"` // The number of permanent hunting task slots.
+	PermanentPreySlots        int    `json:"permanent_prey_slots"`         // The number of permanent prey slots.
+	PreyWildcards             int    `json:"prey_wildcards"`               // The number of prey wildcards.
+	Hirelings                 int    `json:"hirelings"`                    // The number of hirelings.
+	HirelingJobs              int    `json:"hireling_jobs"`                // The number of hireling jobs.
+	HirelingOutfits           int    `json:"hireling_outfits"`             // The number of hireling outfits.
+	ExaltedDust               string `json:"exalted_dust"`                 // The exalted dust and its limit. (e.g. 112/225)
+	BossPoints                int    `json:"boss_points"`                  // The character's boss points.
+}
+
+// Child of BazaarCharacterDetails
+type BazaarCharacterSkill struct {
+	Name     string  `json:"name"`     // The name of the skill.
+	Level    int     `json:"level"`    // The level of the skill.
+	Progress float64 `json:"progress"` // The progress to the next level in percent.
+}
+
+// Child of BazaarItemSummary
+type BazaarItem struct {
+	Name   string `json:"name"`   // The name of the item.
+	Amount int    `json:"amount"` // The amount of the item.
+}
+
+// Child of BazaarCharacterDetails
+type BazaarItemSummary struct {
+	Total int          `json:"total"`           // The total number of different items.
+	Items []BazaarItem `json:"items,omitempty"` // List of items shown on the first page.
+}
+
+// Child of BazaarCharacterDetails
+type BazaarCharm struct {
+	Name string `json:"name"` // The name of the charm.
+	Cost int    `json:"cost"` // The charm points the charm cost.
+}
+
+// Child of BazaarCharacterDetails
+type BazaarAchievement struct {
+	Name   string `json:"name"`   // The name of the achievement.
+	Secret bool   `json:"secret"` // Whether it is a secret achievement or not.
+}
+
+// Child of BazaarCharacterDetails
+type BazaarBestiaryEntry struct {
+	Name      string `json:"name"`      // The name of the creature.
+	Kills     int    `json:"kills"`     // The number of kills of the creature.
+	Step      int    `json:"step"`      // The unlocked step of the bestiary entry.
+	Completed bool   `json:"completed"` // Whether the bestiary entry is completed or not.
+}
+
+// Child of BazaarAuctionDetails
+type BazaarCharacterDetails struct {
+	General      BazaarCharacterGeneral `json:"general"`                // The character's general information.
+	Skills       []BazaarCharacterSkill `json:"skills"`                 // List of the character's skills.
+	Items        BazaarItemSummary      `json:"items"`                  // The character's items.
+	StoreItems   BazaarItemSummary      `json:"store_items"`            // The character's store items.
+	Mounts       int                    `json:"mounts"`                 // The number of mounts.
+	StoreMounts  int                    `json:"store_mounts"`           // The number of store mounts.
+	Outfits      int                    `json:"outfits"`                // The number of outfits.
+	StoreOutfits int                    `json:"store_outfits"`          // The number of store outfits.
+	Charms       []BazaarCharm          `json:"charms,omitempty"`       // List of unlocked charms.
+	Imbuements   []string               `json:"imbuements,omitempty"`   // List of unlocked imbuements.
+	QuestLines   []string               `json:"quest_lines,omitempty"`  // List of completed quest lines.
+	Achievements []BazaarAchievement    `json:"achievements,omitempty"` // List of achievements.
+	Bestiary     []BazaarBestiaryEntry  `json:"bestiary,omitempty"`     // List of the bestiary progress.
+}
+
+// Child of JSONData
+type BazaarAuctionDetails struct {
+	Auction BazaarAuction          `json:"auction"` // The auction with the character's name, vocation, world etc.
+	Details BazaarCharacterDetails `json:"details"` // The details of the character.
+}
+
+// The base includes two levels: BazaarAuctionDetails and Information
+type BazaarAuctionDetailsResponse struct {
+	BazaarAuctionDetails BazaarAuctionDetails `json:"bazaar_auction"`
+	Information          Information          `json:"information"`
+}
+
+func TibiaBazaarAuctionImpl(BoxContentHTML string, url string) (BazaarAuctionDetailsResponse, error) {
+	// Creating empty vars
+	var DetailsData BazaarCharacterDetails
+
+	// Loading HTML data into ReaderHTML for goquery with NewReader
+	ReaderHTML, err := goquery.NewDocumentFromReader(strings.NewReader(BoxContentHTML))
+	if err != nil {
+		return BazaarAuctionDetailsResponse{}, fmt.Errorf("[error] TibiaBazaarAuctionImpl failed at goquery.NewDocumentFromReader, err: %s", err)
+	}
+
+	// the auction header is the same as in the listings
+	AuctionsData := tibiaBazaarParseAuctionList(ReaderHTML)
+	if len(AuctionsData) == 0 {
+		return BazaarAuctionDetailsResponse{}, validation.ErrorAuctionNotFound
+	}
+
+	// general stats are label and value pairs
+	ReaderHTML.Find("#General .LabelV").Each(func(index int, s *goquery.Selection) {
+		RowValue := strings.TrimSpace(TibiaDataSanitizeStrings(s.Next().Text()))
+
+		general := &DetailsData.General
+		switch TibiaDataSanitizeStrings(s.Text()) {
+		case "Hit Points:":
+			general.HitPoints = TibiaDataStringToInteger(RowValue)
+		case "Mana:":
+			general.Mana = TibiaDataStringToInteger(RowValue)
+		case "Capacity:":
+			general.Capacity = TibiaDataStringToInteger(RowValue)
+		case "Speed:":
+			general.Speed = TibiaDataStringToInteger(RowValue)
+		case "Blessings:":
+			general.Blessings = RowValue
+		case "Titles:":
+			general.Titles = TibiaDataStringToInteger(RowValue)
+		case "Creation Date:":
+			general.CreationDate = TibiaDataDatetime(RowValue)
+		case "Experience:":
+			general.Experience = TibiaDataStringToInteger(RowValue)
+		case "Gold:":
+			general.Gold = TibiaDataStringToInteger(RowValue)
+		case "Achievement Points:":
+			general.AchievementPoints = TibiaDataStringToInteger(RowValue)
+		case "Regular World Transfer:":
+			general.RegularWorldTransfer = RowValue
+		case "Charm Expansion:":
+			general.CharmExpansion = RowValue == "yes"
+		case "Available Charm Points:":
+			general.AvailableCharmPoints = TibiaDataStringToInteger(RowValue)
+		case "Spent Charm Points:":
+			general.SpentCharmPoints = TibiaDataStringToInteger(RowValue)
+		case "Daily Reward Streak:":
+			general.DailyRewardStreak = TibiaDataStringToInteger(RowValue)
+		case "Hunting Task Points:":
+			general.HuntingTaskPoints = TibiaDataStringToInteger(RowValue)
+		case "Permanent Hunting Task Slots:":
+			general.PermanentHuntingTaskSlots = TibiaDataStringToInteger(RowValue)
+		case "Permanent Prey Slots:":
+			general.PermanentPreySlots = TibiaDataStringToInteger(RowValue)
+		case "Prey Wildcards:":
+			general.PreyWildcards = TibiaDataStringToInteger(RowValue)
+		case "Hirelings:":
+			general.Hirelings = TibiaDataStringToInteger(RowValue)
+		case "Hireling Jobs:":
+			general.HirelingJobs = TibiaDataStringToInteger(RowValue)
+		case "Hireling Outfits:":
+			general.HirelingOutfits = TibiaDataStringToInteger(RowValue)
+		case "Exalted Dust:":
+			general.ExaltedDust = RowValue
+		case "Boss Points:":
+			general.BossPoints = TibiaDataStringToInteger(RowValue)
+		}
+	})
+
+	// skills with level and percentage bar
+	ReaderHTML.Find("#General .LabelColumn").Each(func(index int, s *goquery.Selection) {
+		progress := strings.TrimSpace(strings.TrimSuffix(TibiaDataSanitizeStrings(s.Parent().Find(".PercentageString").Text()), "%"))
+		percent, _ := strconv.ParseFloat(progress, 64)
+
+		DetailsData.Skills = append(DetailsData.Skills, BazaarCharacterSkill{
+			Name:     TibiaDataSanitizeStrings(s.Text()),
+			Level:    TibiaDataStringToInteger(s.Parent().Find(".LevelColumn").Text()),
+			Progress: percent,
+		})
+	})
+
+	DetailsData.Items = tibiaBazaarParseItemSummary(ReaderHTML.Find("#ItemSummary"))
+	DetailsData.StoreItems = tibiaBazaarParseItemSummary(ReaderHTML.Find("#StoreItemSummary"))
+
+	// only the amount of outfits and mounts is of interest
+	DetailsData.Mounts = tibiaBazaarParsePage(ReaderHTML.Find("#Mounts")).TotalResults
+	DetailsData.StoreMounts = tibiaBazaarParsePage(ReaderHTML.Find("#StoreMounts")).TotalResults
+	DetailsData.Outfits = tibiaBazaarParsePage(ReaderHTML.Find("#Outfits")).TotalResults
+	DetailsData.StoreOutfits = tibiaBazaarParsePage(ReaderHTML.Find("#StoreOutfits")).TotalResults
+
+	tibiaBazaarDetailsRows(ReaderHTML, "#Charms", func(columns *goquery.Selection) {
+		if columns.Length() < 2 {
+			return
+		}
+
+		DetailsData.Charms = append(DetailsData.Charms, BazaarCharm{
+			Name: strings.TrimSpace(TibiaDataSanitizeStrings(columns.Eq(1).Text())),
+			Cost: TibiaDataStringToInteger(columns.Eq(0).Text()),
+		})
+	})
+
+	tibiaBazaarDetailsRows(ReaderHTML, "#Imbuements", func(columns *goquery.Selection) {
+		DetailsData.Imbuements = append(DetailsData.Imbuements, strings.TrimSpace(TibiaDataSanitizeStrings(columns.First().Text())))
+	})
+
+	tibiaBazaarDetailsRows(ReaderHTML, "#CompletedQuestLines", func(columns *goquery.Selection) {
+		DetailsData.QuestLines = append(DetailsData.QuestLines, strings.TrimSpace(TibiaDataSanitizeStrings(columns.First().Text())))
+	})
+
+	tibiaBazaarDetailsRows(ReaderHTML, "#Achievements", func(columns *goquery.Selection) {
+		DetailsData.Achievements = append(DetailsData.Achievements, BazaarAchievement{
+			Name: strings.TrimSpace(TibiaDataSanitizeStrings(columns.First().Text())),
+			// secret achievements are marked with an icon
+			Secret: columns.First().Find("img").Length() > 0,
+		})
+	})
+
+	tibiaBazaarDetailsRows(ReaderHTML, "#BestiaryProgress", func(columns *goquery.Selection) {
+		if columns.Length() < 3 {
+			return
+		}
+
+		step := TibiaDataStringToInteger(columns.Eq(0).Text())
+		DetailsData.Bestiary = append(DetailsData.Bestiary, BazaarBestiaryEntry{
+			Name:      strings.TrimSpace(TibiaDataSanitizeStrings(columns.Eq(2).Text())),
+			Kills:     TibiaDataStringToInteger(strings.TrimSuffix(strings.TrimSpace(columns.Eq(1).Text()), " x")),
+			Step:      step,
+			Completed: step == 4,
+		})
+	})
+
+	//
+	// Build the data-blob
+	return BazaarAuctionDetailsResponse{
+		BazaarAuctionDetails{
+			Auction: AuctionsData[0],
+			Details: DetailsData,
+		},
+		Information{
+			APIDetails: TibiaDataAPIDetails,
+			Timestamp:  TibiaDataDatetime(""),
+			TibiaURLs:  []string{url},
+			Status: Status{
+				HTTPCode: http.StatusOK,
+			},
+		},
+	}, nil
+}
+
+// tibiaBazaarParseItemSummary func - parses the items shown in an item block of an auction
+func tibiaBazaarParseItemSummary(s *goquery.Selection) BazaarItemSummary {
+	summary := BazaarItemSummary{
+		Total: tibiaBazaarParsePage(s).TotalResults,
+	}
+
+	s.Find(".CVIcon").Each(func(index int, s *goquery.Selection) {
+		// the first line of the tooltip is the name of the item
+		title, _ := s.Attr("title")
+		name, _, _ := strings.Cut(TibiaDataSanitizeStrings(title), "\n")
+
+		item := BazaarItem{
+			Name:   strings.TrimSpace(name),
+			Amount: 1,
+		}
+		if amount := s.Find(".ObjectAmount"); amount.Length() > 0 {
+			item.Amount = TibiaDataStringToInteger(amount.Text())
+		}
+
+		summary.Items = append(summary.Items, item)
+	})
+
+	return summary
+}
+
+// tibiaBazaarDetailsRows func - calls parse with the columns of each row of a details block
+func tibiaBazaarDetailsRows(ReaderHTML *goquery.Document, block string, parse func(columns *goquery.Selection)) {
+	ReaderHTML.Find(block + " .TableContent tr").Not(".LabelH").Each(func(index int, s *goquery.Selection) {
+		columns := s.Find("td")
+		if columns.Length() == 0 {
+			return
+		}
+
+		parse(columns)
+	})
+}
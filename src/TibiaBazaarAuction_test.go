@@ -0,0 +1,190 @@
+package main
+
+import (
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/static"
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+func TestBazaarAuctionNirvaldoBrakestar(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/bazaar/auction.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	auctionJson, err := TibiaBazaarAuctionImpl(string(data), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	auction := auctionJson.BazaarAuctionDetails.Auction
+	details := auctionJson.BazaarAuctionDetails.Details
+
+	assert.Equal(1513302, auction.AuctionID)
+	assert.Equal("Nirvaldo Brakestar", auction.Name)
+	assert.Equal(532, auction.Level)
+	assert.Equal("Elite Knight", auction.Vocation)
+	assert.Equal("Antica", auction.World)
+	assert.Equal(17600, auction.Bid)
+	assert.Equal("current", auction.BidStatus)
+
+	general := details.General
+	assert.Equal(10795, general.HitPoints)
+	assert.Equal(3250, general.Mana)
+	assert.Equal(12890, general.Capacity)
+	assert.Equal(381, general.Speed)
+	assert.Equal("7/8", general.Blessings)
+	assert.Equal(12, general.Titles)
+	assert.Equal("2011-07-03T16:51:20Z", general.CreationDate)
+	assert.Equal(2487661340, general.Experience)
+	assert.Equal(1231006, general.Gold)
+	assert.Equal(641, general.AchievementPoints)
+	assert.Equal("can be purchased and used immediately", general.RegularWorldTransfer)
+	assert.True(general.CharmExpansion)
+	assert.Equal(1540, general.AvailableCharmPoints)
+	assert.Equal(10200, general.SpentCharmPoints)
+	assert.Equal(43, general.DailyRewardStreak)
+	assert.Equal(28310, general.HuntingTaskPoints)
+	assert.Equal(1, general.PermanentHuntingTaskSlots)
+	assert.Equal(1, general.PermanentPreySlots)
+	assert.Equal(57, general.PreyWildcards)
+	assert.Equal(2, general.Hirelings)
+	assert.Equal(3, general.HirelingJobs)
+	assert.Equal(1, general.HirelingOutfits)
+	assert.Equal("112/225", general.ExaltedDust)
+	assert.Equal(4210, general.BossPoints)
+
+	assert.Equal(8, len(details.Skills))
+	assert.Equal("Axe Fighting", details.Skills[0].Name)
+	assert.Equal(22, details.Skills[0].Level)
+	assert.Equal(0.0, details.Skills[0].Progress)
+	assert.Equal("Magic Level", details.Skills[5].Name)
+	assert.Equal(12, details.Skills[5].Level)
+	assert.Equal(84.10, details.Skills[5].Progress)
+	assert.Equal("Sword Fighting", details.Skills[7].Name)
+	assert.Equal(124, details.Skills[7].Level)
+	assert.Equal(3.07, details.Skills[7].Progress)
+
+	assert.Equal(137, details.Items.Total)
+	assert.Equal(3, len(details.Items.Items))
+	assert.Equal("Magic Plate Armor", details.Items.Items[0].Name)
+	assert.Equal(1, details.Items.Items[0].Amount)
+	assert.Equal("Great Health Potion", details.Items.Items[2].Name)
+	assert.Equal(1250, details.Items.Items[2].Amount)
+	assert.Equal(1, details.StoreItems.Total)
+	assert.Equal("Gold Pouch", details.StoreItems.Items[0].Name)
+
+	assert.Equal(14, details.Mounts)
+	assert.Equal(0, details.StoreMounts)
+	assert.Equal(31, details.Outfits)
+	assert.Equal(3, details.StoreOutfits)
+
+	assert.Equal(3, len(details.Charms))
+	assert.Equal("Dodge", details.Charms[0].Name)
+	assert.Equal(1800, details.Charms[0].Cost)
+	assert.Equal("Low Blow", details.Charms[2].Name)
+
+	assert.Equal([]string{"Powerful Strike", "Intricate Void", "Powerful Featherweight"}, details.Imbuements)
+	assert.Equal(4, len(details.QuestLines))
+	assert.Equal("Wrath of the Emperor", details.QuestLines[2])
+
+	assert.Equal(3, len(details.Achievements))
+	assert.Equal("Allowance Collector", details.Achievements[0].Name)
+	assert.False(details.Achievements[0].Secret)
+	assert.Equal("Cheesy Priest", details.Achievements[2].Name)
+	assert.True(details.Achievements[2].Secret)
+
+	assert.Equal(3, len(details.Bestiary))
+	assert.Equal("Dragon", details.Bestiary[0].Name)
+	assert.Equal(2500, details.Bestiary[0].Kills)
+	assert.Equal(4, details.Bestiary[0].Step)
+	assert.True(details.Bestiary[0].Completed)
+	assert.Equal("Frost Dragon", details.Bestiary[2].Name)
+	assert.Equal(187, details.Bestiary[2].Kills)
+	assert.False(details.Bestiary[2].Completed)
+}
+
+func TestBazaarAuctionNotFound(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/bazaar/auction_notfound.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	_, err = TibiaBazaarAuctionImpl(string(data), "")
+	assert.Equal(t, validation.ErrorAuctionNotFound, err)
+}
+
+func TestBazaarAuctionLive(t *testing.T) {
+	assert := assert.New(t)
+
+	// the first auction of the current and the past auctions
+	url := "https://www.tibia.com/charactertrade/?subtopic=currentcharactertrades"
+	auctionsJson, err := TibiaBazaarAuctionsImpl(BazaarFilters{Vocation: "all"}, tibiaDataLivePage(t, url, ""), url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEmpty(auctionsJson.BazaarAuctions.AuctionList)
+
+	url = "https://www.tibia.com/charactertrade/?subtopic=pastcharactertrades"
+	historyJson, err := TibiaBazaarHistoryImpl(BazaarFilters{Vocation: "all"}, tibiaDataLivePage(t, url, ""), url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEmpty(historyJson.BazaarHistory.AuctionList)
+
+	if t.Failed() {
+		t.FailNow()
+	}
+
+	for _, tt := range []struct {
+		auctionID int
+		testdata  string
+		finished  bool
+	}{
+		{auctionID: auctionsJson.BazaarAuctions.AuctionList[0].AuctionID, testdata: "bazaar/auction.html"},
+		{auctionID: historyJson.BazaarHistory.AuctionList[0].AuctionID, testdata: "bazaar/auction_finished.html", finished: true},
+	} {
+		url = "https://www.tibia.com/charactertrade/?subtopic=currentcharactertrades&page=details&auctionid=" + strconv.Itoa(tt.auctionID) + "&source=overview"
+		auctionJson, err := TibiaBazaarAuctionImpl(tibiaDataLivePage(t, url, tt.testdata), url)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		auction := auctionJson.BazaarAuctionDetails.Auction
+		details := auctionJson.BazaarAuctionDetails.Details
+
+		assert.Equal(tt.auctionID, auction.AuctionID, tt.testdata)
+		assert.NotEmpty(auction.Name, tt.testdata)
+		assert.Positive(auction.Level, tt.testdata)
+		assert.NotEmpty(auction.Vocation, tt.testdata)
+		assert.NotEmpty(auction.World, tt.testdata)
+		assert.NotEmpty(auction.AuctionEnd, tt.testdata)
+		assert.Positive(auction.Bid, tt.testdata)
+		assert.Equal(tt.finished, auction.Status != "", tt.testdata)
+		assert.Positive(details.General.HitPoints, tt.testdata)
+		assert.Positive(details.General.Capacity, tt.testdata)
+		assert.NotEmpty(details.General.CreationDate, tt.testdata)
+		assert.NotEmpty(details.Skills, tt.testdata)
+	}
+
+	url = "https://www.tibia.com/charactertrade/?subtopic=currentcharactertrades&page=details&auctionid=1&source=overview"
+	_, err = TibiaBazaarAuctionImpl(tibiaDataLivePage(t, url, "bazaar/auction_notfound.html"), url)
+	assert.Equal(validation.ErrorAuctionNotFound, err)
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gin-gonic/gin"
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+// Child of BazaarAuctions
+type BazaarAuction struct {
+	AuctionID    int    `json:"auction_id"`       // The internal ID of the auction.
+	Name         string `json:"name"`             // The name of the character.
+	Level        int    `json:"level"`            // The character's level.
+	Vocation     string `json:"vocation"`         // The character's vocation.
+	Sex          string `json:"sex"`              // The character's sex.
+	World        string `json:"world"`            // The character's world.
+	AuctionStart string `json:"auction_start"`    // The timestamp when the auction started.
+	AuctionEnd   string `json:"auction_end"`      // The timestamp when the auction ends.
+	Bid          int    `json:"bid"`              // The bid in Tibia Coins.
+	BidStatus    string `json:"bid_status"`       // The type of the bid. minimum (no bid yet) / current / winning
+	Status       string `json:"status,omitempty"` // The status of the auction. (only for finished auctions)
+}
+
+// Child of BazaarAuctions
+type BazaarFilters struct {
+	World     string `json:"world,omitempty"`      // The world filtered on.
+	Vocation  string `json:"vocation"`             // The vocation filtered on.
+	LevelFrom int    `json:"level_from,omitempty"` // The minimum level filtered on.
+	LevelTo   int    `json:"level_to,omitempty"`   // The maximum level filtered on.
+	Skill     string `json:"skill,omitempty"`      // The skill filtered on.
+	SkillFrom int    `json:"skill_from,omitempty"` // The minimum skill level filtered on.
+	SkillTo   int    `json:"skill_to,omitempty"`   // The maximum skill level filtered on.
+}
+
+// Child of BazaarAuctions
+type BazaarPage struct {
+	CurrentPage  int `json:"current_page"`  // The current page being displayed.
+	TotalPages   int `json:"total_pages"`   // The total number of pages.
+	TotalResults int `json:"total_records"` // The total amount of auctions.
+}
+
+// Child of JSONData
+type BazaarAuctions struct {
+	Filters     BazaarFilters   `json:"filters"`      // The filters the auctions were searched with.
+	AuctionList []BazaarAuction `json:"auction_list"` // List of auctions.
+	AuctionPage BazaarPage      `json:"auction_page"` // Information of auction pages.
+}
+
+// The base includes two levels: BazaarAuctions and Information
+type BazaarAuctionsResponse struct {
+	BazaarAuctions BazaarAuctions `json:"bazaar"`
+	Information    Information    `json:"information"`
+}
+
+// tibiaBazaarProfessionIDs - the vocation ids of the char bazaar filter, which are sorted alphabetically
+// unlike the ones of the highscores returned by TibiaDataVocationValidator
+var tibiaBazaarProfessionIDs = map[string]string{
+	"all":       "0",
+	"none":      "1",
+	"druids":    "2",
+	"knights":   "3",
+	"paladins":  "4",
+	"sorcerers": "5",
+	"monks":     "6",
+}
+
+func TibiaBazaarAuctionsImpl(filters BazaarFilters, BoxContentHTML string, url string) (BazaarAuctionsResponse, error) {
+	// Loading HTML data into ReaderHTML for goquery with NewReader
+	ReaderHTML, err := goquery.NewDocumentFromReader(strings.NewReader(BoxContentHTML))
+	if err != nil {
+		return BazaarAuctionsResponse{}, fmt.Errorf("[error] TibiaBazaarAuctionsImpl failed at goquery.NewDocumentFromReader, err: %s", err)
+	}
+
+	AuctionsData := tibiaBazaarParseAuctionList(ReaderHTML)
+
+	//
+	// Build the data-blob
+	return BazaarAuctionsResponse{
+		BazaarAuctions{
+			Filters:     filters,
+			AuctionList: AuctionsData,
+			AuctionPage: tibiaBazaarParsePage(ReaderHTML.Selection),
+		},
+		Information{
+			APIDetails: TibiaDataAPIDetails,
+			Timestamp:  TibiaDataDatetime(""),
+			TibiaURLs:  []string{url},
+			Status: Status{
+				HTTPCode: http.StatusOK,
+			},
+		},
+	}, nil
+}
+
+// tibiaBazaarParseAuctionList func - parses all auctions of a char bazaar listing
+func tibiaBazaarParseAuctionList(ReaderHTML *goquery.Document) []BazaarAuction {
+	var AuctionsData []BazaarAuction
+
+	// Running query over each auction
+	ReaderHTML.Find(".Auction").Each(func(index int, s *goquery.Selection) {
+		var auction BazaarAuction
+
+		// getting auction id and name from the details link
+		NameQuery := s.Find(".AuctionCharacterName a")
+		auction.Name = TibiaDataSanitizeStrings(NameQuery.Text())
+		if AuctionURL, exists := NameQuery.Attr("href"); exists {
+			if p, err := url.Parse(AuctionURL); err == nil {
+				auction.AuctionID = TibiaDataStringToInteger(p.Query().Get("auctionid"))
+			}
+		}
+
+		// header line: Level: 532 | Vocation: Elite Knight | Male | World: Antica
+		HeaderQuery := s.Find(".AuctionHeader").Clone()
+		HeaderQuery.Find(".AuctionCharacterName, .AuctionLinks").Remove()
+		for field := range strings.SplitSeq(TibiaDataSanitizeStrings(HeaderQuery.Text()), "|") {
+			field = strings.TrimSpace(field)
+			switch {
+			case strings.HasPrefix(field, "Level: "):
+				auction.Level = TibiaDataStringToInteger(strings.TrimPrefix(field, "Level: "))
+			case strings.HasPrefix(field, "Vocation: "):
+				auction.Vocation = strings.TrimPrefix(field, "Vocation: ")
+			case strings.HasPrefix(field, "World: "):
+				auction.World = strings.TrimPrefix(field, "World: ")
+			case field == "Male" || field == "Female":
+				auction.Sex = field
+			}
+		}
+
+		// short auction data with label and value pairs
+		s.Find(".ShortAuctionData .ShortAuctionDataLabel").Each(func(index int, s *goquery.Selection) {
+			RowValue := strings.TrimSpace(TibiaDataSanitizeStrings(s.Next().Text()))
+
+			switch TibiaDataSanitizeStrings(s.Text()) {
+			case "Auction Start:":
+				auction.AuctionStart = TibiaDataDatetime(RowValue)
+			case "Auction End:":
+				auction.AuctionEnd = TibiaDataDatetime(RowValue)
+			case "Minimum Bid:":
+				auction.Bid = TibiaDataStringToInteger(RowValue)
+				auction.BidStatus = "minimum"
+			case "Current Bid:":
+				auction.Bid = TibiaDataStringToInteger(RowValue)
+				auction.BidStatus = "current"
+			case "Winning Bid:":
+				auction.Bid = TibiaDataStringToInteger(RowValue)
+				auction.BidStatus = "winning"
+			}
+		})
+
+		// finished auctions show their status instead of the bid input
+		auction.Status = strings.TrimSpace(TibiaDataSanitizeStrings(s.Find(".CurrentBid .AuctionInfo").Text()))
+
+		AuctionsData = append(AuctionsData, auction)
+	})
+
+	return AuctionsData
+}
+
+// tibiaBazaarParsePage func - parses the first page navigation found in a char bazaar listing
+func tibiaBazaarParsePage(s *goquery.Selection) BazaarPage {
+	CurrentPage, TotalPages, TotalResults := TibiaDataPageNavigation(s)
+
+	return BazaarPage{
+		CurrentPage:  CurrentPage,
+		TotalPages:   TotalPages,
+		TotalResults: TotalResults,
+	}
+}
+
+// tibiaBazaarFiltersFromQuery func - validates the char bazaar filters of a request and builds the tibia.com query
+func tibiaBazaarFiltersFromQuery(c *gin.Context) (BazaarFilters, string, error) {
+	var (
+		filters BazaarFilters
+		err     error
+	)
+
+	// Check if world exists
+	if world := c.Query("world"); world != "" {
+		// Adding fix for First letter to be upper and rest lower
+		filters.World = TibiaDataStringWorldFormatToTitle(world)
+
+		exists, err := validation.WorldExists(filters.World)
+		if err != nil {
+			return BazaarFilters{}, "", err
+		}
+
+		if !exists {
+			return BazaarFilters{}, "", validation.ErrorWorldDoesNotExist
+		}
+	}
+
+	// Check if vocation is valid
+	vocation := c.DefaultQuery("vocation", TibiaDataDefaultVoc)
+	err = validation.IsVocationValid(vocation)
+	if err != nil {
+		return BazaarFilters{}, "", err
+	}
+
+	// Sanitize of vocation input
+	vocationName, _ := TibiaDataVocationValidator(vocation)
+	filters.Vocation = vocationName
+
+	// Check the level range
+	filters.LevelFrom, filters.LevelTo, err = tibiaDataRangeFromQuery(c, "level_from", "level_to")
+	if err != nil {
+		if err == validation.ErrorStringCanNotBeConvertedToInt {
+			return BazaarFilters{}, "", err
+		}
+		return BazaarFilters{}, "", validation.ErrorLevelRangeInvalid
+	}
+
+	// Check if skill is valid
+	var skillid string
+	if skill := c.Query("skill"); skill != "" {
+		err = validation.IsBazaarSkillValid(skill)
+		if err != nil {
+			return BazaarFilters{}, "", err
+		}
+
+		bazaarSkill := validation.BazaarSkillFromString(skill)
+		filters.Skill, _ = bazaarSkill.String()
+		skillid = strconv.Itoa(int(bazaarSkill))
+	}
+
+	// Check the skill range
+	filters.SkillFrom, filters.SkillTo, err = tibiaDataRangeFromQuery(c, "skill_from", "skill_to")
+	if err != nil {
+		if err == validation.ErrorStringCanNotBeConvertedToInt {
+			return BazaarFilters{}, "", err
+		}
+		return BazaarFilters{}, "", validation.ErrorSkillRangeInvalid
+	}
+
+	// tibia.com ignores the skill range without a skill
+	if skillid == "" && (c.Query("skill_from") != "" || c.Query("skill_to") != "") {
+		return BazaarFilters{}, "", validation.ErrorSkillRangeWithoutSkill
+	}
+
+	query := "&filter_profession=" + TibiaDataQueryEscapeString(tibiaBazaarProfessionIDs[vocationName]) +
+		"&filter_world=" + TibiaDataQueryEscapeString(filters.World) +
+		"&filter_skillid=" + TibiaDataQueryEscapeString(skillid)
+
+	if filters.LevelFrom > 0 {
+		query += "&filter_levelrangefrom=" + strconv.Itoa(filters.LevelFrom)
+	}
+	if filters.LevelTo > 0 {
+		query += "&filter_levelrangeto=" + strconv.Itoa(filters.LevelTo)
+	}
+	if filters.SkillFrom > 0 {
+		query += "&filter_skillrangefrom=" + strconv.Itoa(filters.SkillFrom)
+	}
+	if filters.SkillTo > 0 {
+		query += "&filter_skillrangeto=" + strconv.Itoa(filters.SkillTo)
+	}
+
+	return filters, query, nil
+}
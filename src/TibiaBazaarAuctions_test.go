@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/static"
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+func TestBazaarAuctions(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/bazaar/auctions.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	filters := BazaarFilters{Vocation: "all"}
+	auctionsJson, err := TibiaBazaarAuctionsImpl(filters, string(data), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	bazaar := auctionsJson.BazaarAuctions
+
+	assert.Equal(filters, bazaar.Filters)
+	assert.Equal(2, bazaar.AuctionPage.CurrentPage)
+	assert.Equal(48, bazaar.AuctionPage.TotalPages)
+	assert.Equal(1189, bazaar.AuctionPage.TotalResults)
+	assert.Equal(3, len(bazaar.AuctionList))
+
+	knight := bazaar.AuctionList[0]
+	assert.Equal(1513302, knight.AuctionID)
+	assert.Equal("Nirvaldo Brakestar", knight.Name)
+	assert.Equal(532, knight.Level)
+	assert.Equal("Elite Knight", knight.Vocation)
+	assert.Equal("Male", knight.Sex)
+	assert.Equal("Antica", knight.World)
+	assert.Equal("2024-03-14T09:00:00Z", knight.AuctionStart)
+	assert.Equal("2024-03-21T09:00:00Z", knight.AuctionEnd)
+	assert.Equal(17600, knight.Bid)
+	assert.Equal("current", knight.BidStatus)
+	assert.Empty(knight.Status)
+
+	paladin := bazaar.AuctionList[1]
+	assert.Equal(1513417, paladin.AuctionID)
+	assert.Equal("Lady'Mirra", paladin.Name)
+	assert.Equal(308, paladin.Level)
+	assert.Equal("Royal Paladin", paladin.Vocation)
+	assert.Equal("Female", paladin.Sex)
+	assert.Equal("Premia", paladin.World)
+	assert.Equal("2024-03-24T17:00:00Z", paladin.AuctionEnd)
+	assert.Equal(3000, paladin.Bid)
+	assert.Equal("minimum", paladin.BidStatus)
+
+	monk := bazaar.AuctionList[2]
+	assert.Equal(1513588, monk.AuctionID)
+	assert.Equal("Exura San Kaz", monk.Name)
+	assert.Equal(1024, monk.Level)
+	assert.Equal("Exalted Monk", monk.Vocation)
+	assert.Equal("Secura", monk.World)
+	assert.Equal(211000, monk.Bid)
+	assert.Equal("current", monk.BidStatus)
+}
+
+func TestBazaarAuctionsEmpty(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/bazaar/auctions_empty.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	auctionsJson, err := TibiaBazaarAuctionsImpl(BazaarFilters{Vocation: "all"}, string(data), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+
+	assert.Nil(auctionsJson.BazaarAuctions.AuctionList)
+	assert.Equal(0, auctionsJson.BazaarAuctions.AuctionPage.TotalResults)
+}
+
+func TestBazaarAuctionsRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		query string
+		want  validation.Error
+	}{
+		{query: "?level_from=200&level_to=100", want: validation.ErrorLevelRangeInvalid},
+		{query: "?skill=magiclevel&skill_from=-1", want: validation.ErrorSkillRangeInvalid},
+		{query: "?skill_from=50", want: validation.ErrorSkillRangeWithoutSkill},
+		{query: "?skill_to=100", want: validation.ErrorSkillRangeWithoutSkill},
+		{query: "?page=0", want: validation.ErrorPageInvalid},
+	}
+
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v4/bazaar/auctions"+tc.query, nil)
+
+		tibiaBazaarAuctions(c)
+		assert.Equal(t, http.StatusBadRequest, w.Code, tc.query)
+
+		var jerr OutInformation
+		err := json.Unmarshal(w.Body.Bytes(), &jerr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.EqualValues(t, tc.want.Code(), jerr.Information.Status.Error, tc.query)
+	}
+}
+
+func TestBazaarFiltersQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "", want: "&filter_profession=0&filter_world=&filter_skillid="},
+		{query: "?vocation=none", want: "&filter_profession=1&filter_world=&filter_skillid="},
+		{query: "?vocation=druids", want: "&filter_profession=2&filter_world=&filter_skillid="},
+		{query: "?vocation=knights", want: "&filter_profession=3&filter_world=&filter_skillid="},
+		{query: "?vocation=paladin", want: "&filter_profession=4&filter_world=&filter_skillid="},
+		{query: "?vocation=sorcerer", want: "&filter_profession=5&filter_world=&filter_skillid="},
+		{query: "?vocation=monks&level_from=100&level_to=200", want: "&filter_profession=6&filter_world=&filter_skillid=&filter_levelrangefrom=100&filter_levelrangeto=200"},
+		{query: "?skill=magiclevel&skill_from=50", want: "&filter_profession=0&filter_world=&filter_skillid=1&filter_skillrangefrom=50"},
+	}
+
+	for _, tc := range tests {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/v4/bazaar/auctions"+tc.query, nil)
+
+		_, query, err := tibiaBazaarFiltersFromQuery(c)
+		assert.Nil(t, err, tc.query)
+		assert.Equal(t, tc.want, query, tc.query)
+	}
+}
+
+func TestBazaarAuctionsLive(t *testing.T) {
+	url := "https://www.tibia.com/charactertrade/?subtopic=currentcharactertrades"
+	data := tibiaDataLivePage(t, url, "bazaar/auctions.html")
+
+	auctionsJson, err := TibiaBazaarAuctionsImpl(BazaarFilters{Vocation: "all"}, data, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	bazaar := auctionsJson.BazaarAuctions
+
+	assert.NotEmpty(bazaar.AuctionList)
+	assert.Equal(1, bazaar.AuctionPage.CurrentPage)
+	assert.Positive(bazaar.AuctionPage.TotalPages)
+	assert.Positive(bazaar.AuctionPage.TotalResults)
+	for _, auction := range bazaar.AuctionList {
+		assert.Positive(auction.AuctionID, auction.Name)
+		assert.NotEmpty(auction.Name)
+		assert.Positive(auction.Level, auction.Name)
+		assert.NotEmpty(auction.Vocation, auction.Name)
+		assert.Contains([]string{"Male", "Female"}, auction.Sex, auction.Name)
+		assert.NotEmpty(auction.World, auction.Name)
+		assert.NotEmpty(auction.AuctionStart, auction.Name)
+		assert.NotEmpty(auction.AuctionEnd, auction.Name)
+		assert.Positive(auction.Bid, auction.Name)
+		assert.Contains([]string{"minimum", "current"}, auction.BidStatus, auction.Name)
+	}
+
+	// no character reaches this level, so the list is empty
+	url = "https://www.tibia.com/charactertrade/?subtopic=currentcharactertrades&filter_levelrangefrom=100000"
+	data = tibiaDataLivePage(t, url, "bazaar/auctions_empty.html")
+
+	auctionsJson, err = TibiaBazaarAuctionsImpl(BazaarFilters{Vocation: "all", LevelFrom: 100000}, data, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(auctionsJson.BazaarAuctions.AuctionList)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Child of BazaarHistory
+type BazaarHistoryAuction struct {
+	AuctionID  int    `json:"auction_id"`  // The internal ID of the auction.
+	Name       string `json:"name"`        // The name of the character.
+	Level      int    `json:"level"`       // The character's level.
+	Vocation   string `json:"vocation"`    // The character's vocation.
+	Sex        string `json:"sex"`         // The character's sex.
+	World      string `json:"world"`       // The character's world.
+	AuctionEnd string `json:"auction_end"` // The timestamp when the auction ended.
+	FinalBid   int    `json:"final_bid"`   // The winning bid in Tibia Coins, or the minimum bid if nobody bid.
+	Successful bool   `json:"successful"`  // Whether the character was sold or not.
+	Status     string `json:"status"`      // The status of the auction.
+}
+
+// Child of JSONData
+type BazaarHistory struct {
+	Filters     BazaarFilters          `json:"filters"`      // The filters the auctions were searched with.
+	AuctionList []BazaarHistoryAuction `json:"auction_list"` // List of finished auctions.
+	AuctionPage BazaarPage             `json:"auction_page"` // Information of auction pages.
+}
+
+// The base includes two levels: BazaarHistory and Information
+type BazaarHistoryResponse struct {
+	BazaarHistory BazaarHistory `json:"bazaar_history"`
+	Information   Information   `json:"information"`
+}
+
+func TibiaBazaarHistoryImpl(filters BazaarFilters, BoxContentHTML string, url string) (BazaarHistoryResponse, error) {
+	// Creating empty vars
+	var HistoryData []BazaarHistoryAuction
+
+	// Loading HTML data into ReaderHTML for goquery with NewReader
+	ReaderHTML, err := goquery.NewDocumentFromReader(strings.NewReader(BoxContentHTML))
+	if err != nil {
+		return BazaarHistoryResponse{}, fmt.Errorf("[error] TibiaBazaarHistoryImpl failed at goquery.NewDocumentFromReader, err: %s", err)
+	}
+
+	// finished auctions are listed the same way as the current ones
+	for _, auction := range tibiaBazaarParseAuctionList(ReaderHTML) {
+		HistoryData = append(HistoryData, BazaarHistoryAuction{
+			AuctionID:  auction.AuctionID,
+			Name:       auction.Name,
+			Level:      auction.Level,
+			Vocation:   auction.Vocation,
+			Sex:        auction.Sex,
+			World:      auction.World,
+			AuctionEnd: auction.AuctionEnd,
+			FinalBid:   auction.Bid,
+			// only sold characters have a winning bid
+			Successful: auction.BidStatus == "winning",
+			Status:     auction.Status,
+		})
+	}
+
+	//
+	// Build the data-blob
+	return BazaarHistoryResponse{
+		BazaarHistory{
+			Filters:     filters,
+			AuctionList: HistoryData,
+			AuctionPage: tibiaBazaarParsePage(ReaderHTML.Selection),
+		},
+		Information{
+			APIDetails: TibiaDataAPIDetails,
+			Timestamp:  TibiaDataDatetime(""),
+			TibiaURLs:  []string{url},
+			Status: Status{
+				HTTPCode: http.StatusOK,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/static"
+)
+
+func TestBazaarHistory(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/bazaar/history.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	filters := BazaarFilters{Vocation: "all"}
+	historyJson, err := TibiaBazaarHistoryImpl(filters, string(data), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	history := historyJson.BazaarHistory
+
+	assert.Equal(filters, history.Filters)
+	assert.Equal(1, history.AuctionPage.CurrentPage)
+	assert.Equal(5613, history.AuctionPage.TotalPages)
+	assert.Equal(168371, history.AuctionPage.TotalResults)
+	assert.Equal(3, len(history.AuctionList))
+
+	sold := history.AuctionList[0]
+	assert.Equal(1498011, sold.AuctionID)
+	assert.Equal("Sir Tomasz", sold.Name)
+	assert.Equal(412, sold.Level)
+	assert.Equal("Elite Knight", sold.Vocation)
+	assert.Equal("Male", sold.Sex)
+	assert.Equal("Antica", sold.World)
+	assert.Equal("2024-03-08T09:00:00Z", sold.AuctionEnd)
+	assert.Equal(9450, sold.FinalBid)
+	assert.True(sold.Successful)
+	assert.Equal("finished", sold.Status)
+
+	unsold := history.AuctionList[1]
+	assert.Equal("Mala Sorcia", unsold.Name)
+	assert.Equal("Master Sorcerer", unsold.Vocation)
+	assert.Equal(1500, unsold.FinalBid)
+	assert.False(unsold.Successful)
+	assert.Equal("finished", unsold.Status)
+
+	transfer := history.AuctionList[2]
+	assert.Equal("Dorf Pyrius", transfer.Name)
+	assert.Equal("2024-03-10T17:00:00Z", transfer.AuctionEnd)
+	assert.Equal(64000, transfer.FinalBid)
+	assert.True(transfer.Successful)
+	assert.Equal("will be transferred at the next server save", transfer.Status)
+}
+
+func TestBazaarHistoryLive(t *testing.T) {
+	url := "https://www.tibia.com/charactertrade/?subtopic=pastcharactertrades"
+	data := tibiaDataLivePage(t, url, "bazaar/history.html")
+
+	historyJson, err := TibiaBazaarHistoryImpl(BazaarFilters{Vocation: "all"}, data, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	history := historyJson.BazaarHistory
+
+	assert.NotEmpty(history.AuctionList)
+	assert.Equal(1, history.AuctionPage.CurrentPage)
+	assert.Positive(history.AuctionPage.TotalPages)
+	assert.Positive(history.AuctionPage.TotalResults)
+	for _, auction := range history.AuctionList {
+		assert.Positive(auction.AuctionID, auction.Name)
+		assert.NotEmpty(auction.Name)
+		assert.Positive(auction.Level, auction.Name)
+		assert.NotEmpty(auction.Vocation, auction.Name)
+		assert.Contains([]string{"Male", "Female"}, auction.Sex, auction.Name)
+		assert.NotEmpty(auction.World, auction.Name)
+		assert.NotEmpty(auction.AuctionEnd, auction.Name)
+		assert.Positive(auction.FinalBid, auction.Name)
+		assert.NotEmpty(auction.Status, auction.Name)
+	}
+}
@@ -102,9 +102,17 @@ type OtherCharacters struct {
 	Position string `json:"position,omitempty"` // // The character's special position.
 }
 
+// Child of Character
+type CharacterStatus struct {
+	State         string `json:"state"`                    // The state of the character. (active, renamed or scheduled_for_deletion)
+	RequestedName string `json:"requested_name,omitempty"` // The name the character was requested with.
+	FormerName    string `json:"former_name,omitempty"`    // The former name the character was found by. (when renamed)
+}
+
 // Child of JSONData
 type Character struct {
 	CharacterInfo      CharacterInfo      `json:"character"`                     // The character's information.
+	CharacterStatus    CharacterStatus    `json:"status"`                        // The character's status compared to the requested name.
 	AccountBadges      []AccountBadges    `json:"account_badges,omitempty"`      // The account's badges.
 	Achievements       []Achievements     `json:"achievements,omitempty"`        // The character's achievements.
 	Deaths             []Deaths           `json:"deaths,omitempty"`              // The character's deaths.
@@ -125,8 +133,15 @@ type CharacterResponse struct {
 // best to just simply use the Br constant value.
 const Br = 0x202
 
+// Character states
+const (
+	CharacterStateActive               = "active"
+	CharacterStateRenamed              = "renamed"
+	CharacterStateScheduledForDeletion = "scheduled_for_deletion"
+)
+
 // TibiaCharactersCharacter func
-func TibiaCharactersCharacterImpl(BoxContentHTML string, url string) (CharacterResponse, error) {
+func TibiaCharactersCharacterImpl(requestedName string, BoxContentHTML string, url string) (CharacterResponse, error) {
 	var (
 		// local strings used in this function
 		localDivQueryString = ".TableContentContainer tr"
@@ -723,6 +738,7 @@ func TibiaCharactersCharacterImpl(BoxContentHTML string, url string) (CharacterR
 	// Build the character data
 	charData := Character{
 		CharacterInfoData,
+		CharacterStatus{},
 		AccountBadgesData,
 		AchievementsData,
 		DeathsData,
@@ -749,6 +765,9 @@ func TibiaCharactersCharacterImpl(BoxContentHTML string, url string) (CharacterR
 		return CharacterResponse{}, validation.ErrorCharacterNotFound
 	}
 
+	// tibia.com shows the current character when searching for a former name
+	charData.CharacterStatus = tibiaCharactersCharacterStatus(requestedName, CharacterInfoData)
+
 	//
 	// Build the data-blob
 	return CharacterResponse{
@@ -764,6 +783,32 @@ func TibiaCharactersCharacterImpl(BoxContentHTML string, url string) (CharacterR
 	}, nil
 }
 
+// tibiaCharactersCharacterStatus func - compares the requested name with the character tibia.com returned
+func tibiaCharactersCharacterStatus(requestedName string, character CharacterInfo) CharacterStatus {
+	requestedName = strings.TrimSpace(strings.ReplaceAll(requestedName, "+", " "))
+
+	status := CharacterStatus{
+		State:         CharacterStateActive,
+		RequestedName: requestedName,
+	}
+
+	if requestedName != "" && !strings.EqualFold(requestedName, character.Name) {
+		for _, formerName := range character.FormerNames {
+			if strings.EqualFold(requestedName, formerName) {
+				status.State = CharacterStateRenamed
+				status.FormerName = formerName
+				break
+			}
+		}
+	}
+
+	if character.DeletionDate != "" {
+		status.State = CharacterStateScheduledForDeletion
+	}
+
+	return status
+}
+
 // TibiaDataParseKiller func - insert a html string and get the killers back
 func TibiaDataParseKiller(data string) (string, bool, bool, string) {
 	var (
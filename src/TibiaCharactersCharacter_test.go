@@ -5,8 +5,11 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -26,7 +29,7 @@ func TestNumber1(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "https://www.tibia.com/community/?subtopic=characters&name=Darkside+Rafa")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "https://www.tibia.com/community/?subtopic=characters&name=Darkside+Rafa")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -71,7 +74,7 @@ func TestNumber2(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -137,7 +140,7 @@ func TestNumber3(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -172,7 +175,7 @@ func TestNumber4(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2787,7 +2790,7 @@ func TestNumber5(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2841,7 +2844,7 @@ func TestNumber6(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2891,7 +2894,7 @@ func TestNumber7(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2916,7 +2919,7 @@ func TestNumber8(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3076,7 +3079,7 @@ func TestNumber9(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3178,7 +3181,7 @@ func TestNumber10(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3207,7 +3210,7 @@ func TestNumber11(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3288,7 +3291,7 @@ func TestNumber12(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3313,7 +3316,7 @@ func TestNumber13(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3401,7 +3404,7 @@ func TestNumber14(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3750,7 +3753,7 @@ func TestNumber15(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3840,7 +3843,7 @@ func TestNumber16(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -4009,7 +4012,7 @@ func TestNumber17(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -4087,7 +4090,7 @@ func TestNumber18(t *testing.T) {
 		t.Fatalf("File reading error: %s", err)
 	}
 
-	characterJson, err := TibiaCharactersCharacterImpl(string(data), "")
+	characterJson, err := TibiaCharactersCharacterImpl("", string(data), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -4165,6 +4168,178 @@ func TestNumber18(t *testing.T) {
 	}
 }
 
+func TestCharacterStatus(t *testing.T) {
+	tests := []struct {
+		file          string
+		requestedName string
+		want          CharacterStatus
+	}{
+		{
+			file:          "Darkside Rafa",
+			requestedName: "darkside+rafa",
+			want: CharacterStatus{
+				State:         CharacterStateActive,
+				RequestedName: "darkside rafa",
+			},
+		},
+		{
+			file:          "Riley No Hands",
+			requestedName: "Dura Malandro",
+			want: CharacterStatus{
+				State:         CharacterStateRenamed,
+				RequestedName: "Dura Malandro",
+				FormerName:    "Dura Malandro",
+			},
+		},
+		{
+			file:          "Borttagna Gubben",
+			requestedName: "Borttagna Gubben",
+			want: CharacterStatus{
+				State:         CharacterStateScheduledForDeletion,
+				RequestedName: "Borttagna Gubben",
+			},
+		},
+		{
+			file:          "Darkside Rafa",
+			requestedName: "",
+			want: CharacterStatus{
+				State: CharacterStateActive,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		file, err := static.TestFiles.Open("testdata/characters/" + tc.file + ".html")
+		if err != nil {
+			t.Fatalf("file opening error: %s", err)
+		}
+
+		data, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			t.Fatalf("File reading error: %s", err)
+		}
+
+		characterJson, err := TibiaCharactersCharacterImpl(tc.requestedName, string(data), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, tc.want, characterJson.Character.CharacterStatus, "requested name: %s", tc.requestedName)
+	}
+}
+
+func TestCharacterLookupStates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	assert := assert.New(t)
+
+	pages := map[string]string{}
+	for _, name := range []string{"Darkside Rafa", "Riley No Hands"} {
+		data, err := static.TestFiles.ReadFile("testdata/characters/" + name + ".html")
+		if err != nil {
+			t.Fatalf("file reading error: %s", err)
+		}
+		pages[name] = string(data)
+	}
+	notFound := `<div class="TableContainer"><div class="Text">Could not find character</div></div>`
+
+	// the page tibia.com shows for every requested name
+	var mu sync.Mutex
+	shown := map[string]string{
+		"Riley No Hands": pages["Riley No Hands"],
+		"Dura Malandro":  pages["Riley No Hands"],
+	}
+	show := func(name, page string) {
+		mu.Lock()
+		defer mu.Unlock()
+		shown[name] = page
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		page, ok := shown[r.URL.Query().Get("name")]
+		mu.Unlock()
+		if !ok {
+			page = notFound
+		}
+		_, _ = w.Write([]byte(`<div class="Border_2"><div class="Border_3">` + page + `</div></div>`))
+	}))
+	defer server.Close()
+
+	proxies := TibiaDataProxies
+	TibiaDataProxies = NewTibiaDataProxyPool([]string{server.URL + "/"}, TibiaDataProxyRoundRobin, 100, time.Minute, time.Minute)
+	defer func() { TibiaDataProxies = proxies }()
+
+	cacheEnabled := TibiaDataCacheEnabled
+	TibiaDataCacheEnabled = false
+	defer func() { TibiaDataCacheEnabled = cacheEnabled }()
+
+	history := TibiaDataHistory
+	var err error
+	TibiaDataHistory, err = NewTibiaDataHistoryStore(t.TempDir(), time.Hour, 0)
+	assert.Nil(err)
+	defer func() { TibiaDataHistory = history }()
+
+	request := func(name, query string) (int, CharacterResponse, OutInformation) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v4/character/"+url.PathEscape(name)+"?"+query, nil)
+		c.Params = []gin.Param{{Key: "name", Value: name}}
+		tibiaCharactersCharacter(c)
+
+		var response CharacterResponse
+		var jerr OutInformation
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		_ = json.Unmarshal(w.Body.Bytes(), &jerr)
+		return w.Code, response, jerr
+	}
+
+	// the character and its former names are recorded
+	code, _, _ := request("Riley No Hands", "")
+	assert.Equal(http.StatusOK, code)
+	assert.Eventually(func() bool {
+		owner, _ := tibiaDataHistoryFormerOwner("Dura Malandro")
+		return owner == "Riley No Hands"
+	}, time.Second, 10*time.Millisecond)
+
+	// former names are not followed on request
+	code, _, jerr := request("Dura Malandro", "follow_former_names=false")
+	assert.Equal(http.StatusBadRequest, code)
+	assert.Equal(validation.ErrorCharacterRenamed.Code(), jerr.Information.Status.Error)
+
+	// the former name now belongs to another character
+	show("Dura Malandro", pages["Darkside Rafa"])
+	code, response, _ := request("Dura Malandro", "")
+	assert.Equal(http.StatusOK, code)
+	assert.Equal("Darkside Rafa", response.Character.CharacterInfo.Name)
+
+	code, response, _ = request("Dura Malandro", "former_owner=true")
+	assert.Equal(http.StatusOK, code)
+	assert.Equal("Riley No Hands", response.Character.CharacterInfo.Name)
+	assert.Equal(CharacterStatus{State: CharacterStateRenamed, RequestedName: "Dura Malandro", FormerName: "Dura Malandro"}, response.Character.CharacterStatus)
+
+	// recorded characters that do not exist anymore were deleted or banished
+	show("Riley No Hands", notFound)
+	code, _, jerr = request("Riley No Hands", "")
+	assert.Equal(http.StatusBadRequest, code)
+	assert.Equal(validation.ErrorCharacterDeleted.Code(), jerr.Information.Status.Error)
+
+	code, _, jerr = request("Never Existed", "")
+	assert.Equal(http.StatusBadGateway, code)
+	assert.Equal(validation.ErrorCharacterNotFound.Code(), jerr.Information.Status.Error)
+
+	// the lookup of the former owner needs the history
+	TibiaDataHistory = nil
+	code, _, jerr = request("Dura Malandro", "former_owner=true")
+	assert.Equal(http.StatusNotFound, code)
+	assert.Equal(validation.ErrorHistoryDisabled.Code(), jerr.Information.Status.Error)
+
+	// without the history it is unknown whether a character was deleted or never existed
+	code, _, jerr = request("Riley No Hands", "")
+	assert.Equal(http.StatusBadRequest, code)
+	assert.Equal(validation.ErrorCharacterStateUnknown.Code(), jerr.Information.Status.Error)
+}
+
 func BenchmarkNumber1(b *testing.B) {
 	file, err := static.TestFiles.Open("testdata/characters/Darkside Rafa.html")
 	if err != nil {
@@ -4180,7 +4355,7 @@ func BenchmarkNumber1(b *testing.B) {
 	b.ReportAllocs()
 
 	for i := 0; i < b.N; i++ {
-		characterJson, _ := TibiaCharactersCharacterImpl(string(data), "")
+		characterJson, _ := TibiaCharactersCharacterImpl("", string(data), "")
 
 		assert.Equal(b, "Darkside Rafa", characterJson.Character.CharacterInfo.Name)
 	}
@@ -4201,7 +4376,7 @@ func BenchmarkNumber2(b *testing.B) {
 	b.ReportAllocs()
 
 	for i := 0; i < b.N; i++ {
-		characterJson, _ := TibiaCharactersCharacterImpl(string(data), "")
+		characterJson, _ := TibiaCharactersCharacterImpl("", string(data), "")
 
 		assert.Equal(b, "Riley No Hands", characterJson.Character.CharacterInfo.Name)
 	}
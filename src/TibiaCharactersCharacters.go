@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+// Child of CharactersLookup
+type CharactersLookupError struct {
+	Code    int    `json:"code,omitempty"` // The TibiaData error code.
+	Message string `json:"message"`        // The error message.
+}
+
+// Child of JSONData
+type CharactersLookup struct {
+	Character *Character             `json:"character,omitempty"` // The character's data. (when found)
+	Error     *CharactersLookupError `json:"error,omitempty"`     // The reason why the character could not be shown.
+}
+
+// The base includes two levels: Characters and Information
+type CharactersResponse struct {
+	Characters  map[string]CharactersLookup `json:"characters"`
+	Information Information                 `json:"information"`
+}
+
+// Request body of the characters endpoint
+type CharactersRequest struct {
+	Names []string `json:"names"` // List of character names to look up.
+}
+
+// TibiaCharactersCharactersImpl func - looks up all names with at most concurrency requests at a time
+func TibiaCharactersCharactersImpl(names []string, concurrency int, fetch func(name string) (CharacterResponse, error)) CharactersResponse {
+	// Creating empty vars
+	var (
+		CharactersData = make(map[string]CharactersLookup, len(names))
+		TibiaURLs      []string
+		lookupNames    []string
+		spellings      = map[string][]string{} // the requested names per looked up name
+
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		jobs = make(chan string)
+	)
+
+	// validating the names before anything is requested
+	// names are case insensitive on tibia.com, so every spelling of a name is only requested once
+	requested := make(map[string]string, len(names))
+	for _, name := range names {
+		if _, ok := CharactersData[name]; ok {
+			continue
+		}
+
+		if err := validation.IsCharacterNameValid(name); err != nil {
+			CharactersData[name] = tibiaCharactersCharactersError(err)
+			continue
+		}
+
+		// reserving the key so duplicates are only requested once
+		CharactersData[name] = CharactersLookup{}
+		lookupName, ok := requested[strings.ToLower(name)]
+		if !ok {
+			lookupName = name
+			requested[strings.ToLower(name)] = name
+			lookupNames = append(lookupNames, name)
+		}
+		spellings[lookupName] = append(spellings[lookupName], name)
+	}
+
+	for i := 0; i < min(max(concurrency, 1), len(lookupNames)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for name := range jobs {
+				characterJson, err := fetch(name)
+
+				lookup := CharactersLookup{Character: &characterJson.Character}
+				if err != nil {
+					lookup = tibiaCharactersCharactersError(err)
+				}
+
+				mu.Lock()
+				for _, spelling := range spellings[name] {
+					CharactersData[spelling] = lookup
+				}
+				if err == nil {
+					TibiaURLs = append(TibiaURLs, characterJson.Information.TibiaURLs...)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, name := range lookupNames {
+		jobs <- name
+	}
+	close(jobs)
+	wg.Wait()
+
+	//
+	// Build the data-blob
+	return CharactersResponse{
+		CharactersData,
+		Information{
+			APIDetails: TibiaDataAPIDetails,
+			Timestamp:  TibiaDataDatetime(""),
+			TibiaURLs:  TibiaURLs,
+			Status: Status{
+				HTTPCode: http.StatusOK,
+			},
+		},
+	}
+}
+
+// tibiaCharactersCharactersError func - converts an error into the error object of a single character
+func tibiaCharactersCharactersError(err error) CharactersLookup {
+	lookupError := &CharactersLookupError{
+		Message: err.Error(),
+	}
+
+	// the error may be wrapped, e.g. in TibiaDataUnavailableError
+	var validationErr validation.Error
+	if errors.As(err, &validationErr) {
+		lookupError.Code = validationErr.Code()
+	}
+
+	return CharactersLookup{Error: lookupError}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/static"
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+// tibiaCharactersCharactersTestFetch func - reads the character from the testdata instead of tibia.com
+func tibiaCharactersCharactersTestFetch(name string) (CharacterResponse, error) {
+	file, err := static.TestFiles.Open("testdata/characters/" + name + ".html")
+	if err != nil {
+		return CharacterResponse{}, validation.ErrorCharacterNotFound
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return CharacterResponse{}, err
+	}
+
+	return TibiaCharactersCharacterImpl(name, string(data), "https://www.tibia.com/community/?subtopic=characters&name="+TibiaDataQueryEscapeString(name))
+}
+
+func TestCharactersCharacters(t *testing.T) {
+	names := []string{"Darkside Rafa", "Riley No Hands", "Darkside Rafa", "Nonexisting Character", "x"}
+	charactersJson := TibiaCharactersCharactersImpl(names, 2, tibiaCharactersCharactersTestFetch)
+
+	assert := assert.New(t)
+	characters := charactersJson.Characters
+
+	assert.Equal(4, len(characters))
+	assert.Equal(2, len(charactersJson.Information.TibiaURLs))
+	assert.Equal(http.StatusOK, charactersJson.Information.Status.HTTPCode)
+
+	darksideRafa := characters["Darkside Rafa"]
+	assert.Nil(darksideRafa.Error)
+	assert.Equal("Darkside Rafa", darksideRafa.Character.CharacterInfo.Name)
+	assert.Equal(CharacterStateActive, darksideRafa.Character.CharacterStatus.State)
+
+	rileyNoHands := characters["Riley No Hands"]
+	assert.Nil(rileyNoHands.Error)
+	assert.Equal("Riley No Hands", rileyNoHands.Character.CharacterInfo.Name)
+
+	notFound := characters["Nonexisting Character"]
+	assert.Nil(notFound.Character)
+	assert.Equal(validation.ErrorCharacterNotFound.Code(), notFound.Error.Code)
+	assert.Equal(validation.ErrorCharacterNotFound.Error(), notFound.Error.Message)
+
+	invalid := characters["x"]
+	assert.Nil(invalid.Character)
+	assert.Equal(validation.ErrorCharacterNameTooSmall.Code(), invalid.Error.Code)
+}
+
+func TestCharactersCharactersCaseInsensitive(t *testing.T) {
+	var fetches atomic.Int32
+	fetch := func(name string) (CharacterResponse, error) {
+		fetches.Add(1)
+		return tibiaCharactersCharactersTestFetch(name)
+	}
+
+	charactersJson := TibiaCharactersCharactersImpl([]string{"Darkside Rafa", "darkside rafa", "DARKSIDE RAFA"}, 2, fetch)
+
+	assert := assert.New(t)
+	assert.Equal(int32(1), fetches.Load())
+	assert.Equal(3, len(charactersJson.Characters))
+	for _, name := range []string{"Darkside Rafa", "darkside rafa", "DARKSIDE RAFA"} {
+		assert.Nil(charactersJson.Characters[name].Error, name)
+		assert.Equal("Darkside Rafa", charactersJson.Characters[name].Character.CharacterInfo.Name, name)
+	}
+}
+
+func TestCharactersCharactersWrappedError(t *testing.T) {
+	fetch := func(name string) (CharacterResponse, error) {
+		return CharacterResponse{}, TibiaDataUnavailableError{Err: validation.ErrorUpstreamRateLimited, RetryAfter: time.Second}
+	}
+
+	charactersJson := TibiaCharactersCharactersImpl([]string{"Darkside Rafa"}, 1, fetch)
+
+	lookup := charactersJson.Characters["Darkside Rafa"]
+	assert.Nil(t, lookup.Character)
+	assert.Equal(t, validation.ErrorUpstreamRateLimited.Code(), lookup.Error.Code)
+}
+
+func TestCharactersCharactersRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		body string
+		want validation.Error
+	}{
+		{body: "", want: validation.ErrorCharacterNamesEmpty},
+		{body: `{"names":[]}`, want: validation.ErrorCharacterNamesEmpty},
+		{body: `{"names":"Darkside Rafa"}`, want: validation.ErrorCharacterNamesEmpty},
+		{body: `{"names":["` + strings.Repeat(`Darkside Rafa","`, TibiaDataCharactersMaxNames) + `Darkside Rafa"]}`, want: validation.ErrorCharacterNamesTooMany},
+	}
+
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v4/characters", bytes.NewBufferString(tc.body))
+
+		tibiaCharactersCharacters(c)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var jerr OutInformation
+		err := json.Unmarshal(w.Body.Bytes(), &jerr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.EqualValues(t, tc.want.Code(), jerr.Information.Status.Error)
+	}
+}
+
+func TestCharactersCharactersCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	assert := assert.New(t)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		data, err := static.TestFiles.ReadFile("testdata/characters/" + r.URL.Query().Get("name") + ".html")
+		if err != nil {
+			t.Errorf("file reading error: %s", err)
+		}
+		_, _ = w.Write([]byte(`<div class="Border_2"><div class="Border_3">` + string(data) + `</div></div>`))
+	}))
+	defer server.Close()
+
+	proxies := TibiaDataProxies
+	TibiaDataProxies = NewTibiaDataProxyPool([]string{server.URL + "/"}, TibiaDataProxyRoundRobin, 100, time.Minute, time.Minute)
+	defer func() { TibiaDataProxies = proxies }()
+
+	cache := TibiaDataCache
+	TibiaDataCache = NewTibiaDataCacheMemory(10)
+	defer func() { TibiaDataCache = cache }()
+
+	history := TibiaDataHistory
+	var err error
+	TibiaDataHistory, err = NewTibiaDataHistoryStore(t.TempDir(), time.Hour, 0)
+	assert.Nil(err)
+	defer func() { TibiaDataHistory = history }()
+
+	request := func() CharactersResponse {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v4/characters", bytes.NewBufferString(`{"names":["Darkside Rafa","Riley No Hands"]}`))
+
+		tibiaCharactersCharacters(c)
+		assert.Equal(http.StatusOK, w.Code)
+
+		var response CharactersResponse
+		assert.Nil(json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	response := request()
+	assert.Equal("Darkside Rafa", response.Characters["Darkside Rafa"].Character.CharacterInfo.Name)
+	assert.Equal("Riley No Hands", response.Characters["Riley No Hands"].Character.CharacterInfo.Name)
+	assert.EqualValues(2, requests.Load())
+
+	// the characters are taken from the cache the second time
+	response = request()
+	assert.Equal("Darkside Rafa", response.Characters["Darkside Rafa"].Character.CharacterInfo.Name)
+	assert.EqualValues(2, requests.Load())
+
+	// the characters are recorded like single requests
+	assert.Eventually(func() bool {
+		return TibiaDataHistory.Exists("character", "Darkside Rafa") && TibiaDataHistory.Exists("character", "Riley No Hands")
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+)
+
+// periods the progress of a character is grouped by
+const (
+	TibiaCharactersProgressDay  = "day"
+	TibiaCharactersProgressWeek = "week"
+)
+
+// Child of CharacterProgress
+type CharacterProgressPeriod struct {
+	Start            string `json:"start"`                       // The start of the period.
+	LevelStart       int    `json:"level_start"`                 // The character's level at the start of the period.
+	LevelEnd         int    `json:"level_end"`                   // The character's level at the end of the period.
+	LevelChange      int    `json:"level_change"`                // The levels gained (or lost) in the period.
+	ExperienceStart  int    `json:"experience_start,omitempty"`  // The character's experience points at the start of the period. (if on the experience highscores)
+	ExperienceEnd    int    `json:"experience_end,omitempty"`    // The character's experience points at the end of the period. (if on the experience highscores)
+	ExperienceChange int    `json:"experience_change,omitempty"` // The experience points gained (or lost) in the period. (if on the experience highscores)
+}
+
+// Child of CharacterProgress
+type CharacterLevelChange struct {
+	Timestamp string   `json:"timestamp"`        // The time of the snapshot the change was noticed in.
+	Type      string   `json:"type"`             // The type of change (level-up or level-down).
+	FromLevel int      `json:"from_level"`       // The character's level in the snapshot before.
+	ToLevel   int      `json:"to_level"`         // The character's level after the change.
+	Deaths    []Deaths `json:"deaths,omitempty"` // The character's deaths since the snapshot before. (when level-down)
+}
+
+// Child of JSONData
+type CharacterProgress struct {
+	Name         string                    `json:"name"`          // The name of the character.
+	Period       string                    `json:"period"`        // The period the progress is grouped by (day or week).
+	From         string                    `json:"from"`          // The start of the time range.
+	To           string                    `json:"to"`            // The end of the time range.
+	Level        int                       `json:"level"`         // The character's level in the most recent snapshot.
+	Experience   int                       `json:"experience"`    // The character's experience points in the most recent snapshot. (if on the experience highscores)
+	Snapshots    int                       `json:"snapshots"`     // The number of snapshots the progress is based on.
+	Progress     []CharacterProgressPeriod `json:"progress"`      // List of periods, oldest first.
+	LevelChanges []CharacterLevelChange    `json:"level_changes"` // List of level-ups and level-downs, oldest first.
+}
+
+// The base includes two levels: CharacterProgress and Information
+type CharacterProgressResponse struct {
+	CharacterProgress CharacterProgress `json:"progress"`
+	Information       Information       `json:"information"`
+}
+
+// tibiaCharactersProgressPoint is the level and experience of a character at one point in time
+type tibiaCharactersProgressPoint struct {
+	created    time.Time
+	level      int // 0 if the level is taken from the character pages instead
+	experience int // 0 if the snapshot is not of the experience highscores
+}
+
+func TibiaCharactersProgressImpl(name, period string, from, to time.Time) (CharacterProgressResponse, error) {
+	var (
+		points []tibiaCharactersProgressPoint
+		deaths = map[string]Deaths{}
+	)
+
+	// the level of the character pages, which also contain the deaths of the last 30 days
+	characters, err := TibiaDataHistory.Query("character", name, from, to, 0)
+	if err != nil {
+		return CharacterProgressResponse{}, err
+	}
+	for _, snapshot := range characters {
+		var character Character
+		created, err := time.Parse(time.RFC3339, snapshot.Timestamp)
+		if err != nil || json.Unmarshal(snapshot.Data, &character) != nil {
+			continue
+		}
+
+		points = append(points, tibiaCharactersProgressPoint{created: created, level: character.CharacterInfo.Level})
+		for _, death := range character.Deaths {
+			deaths[death.Time] = death
+		}
+	}
+
+	// the level and experience of the experience highscores, which are updated less often than the character pages
+	// so their level is only used if there are no snapshots of the character pages
+	highscores, err := TibiaDataHistory.Query("experience", name, from, to, 0)
+	if err != nil {
+		return CharacterProgressResponse{}, err
+	}
+	for _, snapshot := range highscores {
+		var highscore Highscore
+		created, err := time.Parse(time.RFC3339, snapshot.Timestamp)
+		if err != nil || json.Unmarshal(snapshot.Data, &highscore) != nil {
+			continue
+		}
+
+		point := tibiaCharactersProgressPoint{created: created, level: highscore.Level, experience: highscore.Value}
+		if len(characters) > 0 {
+			point.level = 0
+		}
+		points = append(points, point)
+	}
+
+	sort.SliceStable(points, func(i, j int) bool { return points[i].created.Before(points[j].created) })
+
+	// experience taken before the first snapshot of the character page has the level of that snapshot
+	if first := slices.IndexFunc(points, func(point tibiaCharactersProgressPoint) bool { return point.level > 0 }); first > 0 {
+		for i := range first {
+			points[i].level = points[first].level
+		}
+	}
+
+	progress := CharacterProgress{
+		Name:         name,
+		Period:       period,
+		From:         from.UTC().Format(time.RFC3339),
+		To:           to.UTC().Format(time.RFC3339),
+		Snapshots:    len(points),
+		Progress:     []CharacterProgressPeriod{},
+		LevelChanges: []CharacterLevelChange{},
+	}
+
+	var (
+		previous   tibiaCharactersProgressPoint
+		experience int // the most recent experience points
+		current    *CharacterProgressPeriod
+	)
+	for i, point := range points {
+		if point.level == 0 {
+			point.level = previous.level
+		}
+
+		if previous.level > 0 && point.level != previous.level {
+			change := CharacterLevelChange{
+				Timestamp: point.created.UTC().Format(time.RFC3339),
+				Type:      "level-up",
+				FromLevel: previous.level,
+				ToLevel:   point.level,
+			}
+			if point.level < previous.level {
+				change.Type = "level-down"
+				change.Deaths = tibiaCharactersProgressDeaths(deaths, previous.created, point.created)
+			}
+			progress.LevelChanges = append(progress.LevelChanges, change)
+		}
+
+		// every period starts where the period before ended
+		start := tibiaCharactersProgressPeriodStart(point.created, period).Format(time.RFC3339)
+		if current == nil || current.Start != start {
+			progress.Progress = append(progress.Progress, CharacterProgressPeriod{
+				Start:           start,
+				LevelStart:      point.level,
+				ExperienceStart: point.experience,
+			})
+			current = &progress.Progress[len(progress.Progress)-1]
+			if i > 0 {
+				current.LevelStart = previous.level
+				current.ExperienceStart = experience
+			}
+		}
+
+		if current.LevelStart == 0 {
+			current.LevelStart = point.level
+		}
+		current.LevelEnd = point.level
+		current.LevelChange = current.LevelEnd - current.LevelStart
+		if point.experience > 0 {
+			experience = point.experience
+			if current.ExperienceStart == 0 {
+				current.ExperienceStart = point.experience
+			}
+			current.ExperienceEnd = point.experience
+			current.ExperienceChange = current.ExperienceEnd - current.ExperienceStart
+		}
+
+		previous = point
+	}
+	progress.Level = previous.level
+	progress.Experience = experience
+
+	//
+	// Build the data-blob
+	return CharacterProgressResponse{
+		progress,
+		Information{
+			APIDetails: TibiaDataAPIDetails,
+			Timestamp:  TibiaDataDatetime(""),
+			TibiaURLs:  []string{},
+			Status: Status{
+				HTTPCode: http.StatusOK,
+			},
+		},
+	}, nil
+}
+
+// tibiaCharactersProgressPeriodStart func - returns the start of the day or week (starting on monday) in UTC
+func tibiaCharactersProgressPeriodStart(t time.Time, period string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if period == TibiaCharactersProgressWeek {
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	}
+
+	return day
+}
+
+// tibiaCharactersProgressDeaths func - returns the deaths after since until until, oldest first
+func tibiaCharactersProgressDeaths(deaths map[string]Deaths, since, until time.Time) []Deaths {
+	var result []Deaths
+	for _, death := range deaths {
+		died, err := time.Parse(time.RFC3339, death.Time)
+		if err == nil && died.After(since) && !died.After(until) {
+			result = append(result, death)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Time < result[j].Time })
+
+	return result
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCharactersProgress(t *testing.T) {
+	assert := assert.New(t)
+
+	history := TibiaDataHistory
+	var err error
+	TibiaDataHistory, err = NewTibiaDataHistoryStore(t.TempDir(), time.Hour, 0)
+	assert.Nil(err)
+	defer func() { TibiaDataHistory = history }()
+
+	save := func(kind, timestamp string, data interface{}) {
+		raw, err := json.Marshal(data)
+		assert.Nil(err)
+		assert.Nil(TibiaDataHistory.Save(kind, "Trollefar", TibiaDataSnapshot{Timestamp: timestamp, Data: raw}))
+	}
+
+	// the level of the highscores lags behind, so the one of the character pages is used
+	save("character", "2026-01-05T10:00:00Z", Character{CharacterInfo: CharacterInfo{Name: "Trollefar", Level: 100}})
+	save("experience", "2026-01-05T11:00:00Z", Highscore{Name: "Trollefar", Level: 99, Value: 15000000})
+	save("character", "2026-01-05T20:00:00Z", Character{CharacterInfo: CharacterInfo{Name: "Trollefar", Level: 102}})
+	save("experience", "2026-01-06T11:00:00Z", Highscore{Name: "Trollefar", Level: 102, Value: 16500000})
+	save("character", "2026-01-12T09:00:00Z", Character{
+		CharacterInfo: CharacterInfo{Name: "Trollefar", Level: 101},
+		Deaths: []Deaths{
+			{Time: "2026-01-12T08:30:00Z", Level: 102, Reason: "Died at Level 102 by a dragon lord."},
+			{Time: "2026-01-01T08:30:00Z", Level: 95, Reason: "Died at Level 95 by a dragon."},
+		},
+	})
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	response, err := TibiaCharactersProgressImpl("trollefar", TibiaCharactersProgressDay, from, to)
+	assert.Nil(err)
+
+	progress := response.CharacterProgress
+	assert.Equal("day", progress.Period)
+	assert.Equal("2026-01-01T00:00:00Z", progress.From)
+	assert.Equal(5, progress.Snapshots)
+	assert.Equal(101, progress.Level)
+	assert.Equal(16500000, progress.Experience)
+
+	assert.Equal([]CharacterProgressPeriod{
+		{Start: "2026-01-05T00:00:00Z", LevelStart: 100, LevelEnd: 102, LevelChange: 2, ExperienceStart: 15000000, ExperienceEnd: 15000000},
+		{Start: "2026-01-06T00:00:00Z", LevelStart: 102, LevelEnd: 102, ExperienceStart: 15000000, ExperienceEnd: 16500000, ExperienceChange: 1500000},
+		{Start: "2026-01-12T00:00:00Z", LevelStart: 102, LevelEnd: 101, LevelChange: -1, ExperienceStart: 16500000},
+	}, progress.Progress)
+
+	assert.Len(progress.LevelChanges, 2)
+	assert.Equal(CharacterLevelChange{Timestamp: "2026-01-05T20:00:00Z", Type: "level-up", FromLevel: 100, ToLevel: 102}, progress.LevelChanges[0])
+	assert.Equal("level-down", progress.LevelChanges[1].Type)
+	assert.Equal(102, progress.LevelChanges[1].FromLevel)
+	assert.Equal(101, progress.LevelChanges[1].ToLevel)
+	assert.Len(progress.LevelChanges[1].Deaths, 1)
+	assert.Equal("2026-01-12T08:30:00Z", progress.LevelChanges[1].Deaths[0].Time)
+
+	// weeks start on monday
+	response, err = TibiaCharactersProgressImpl("Trollefar", TibiaCharactersProgressWeek, from, to)
+	assert.Nil(err)
+	assert.Len(response.CharacterProgress.Progress, 2)
+	assert.Equal("2026-01-05T00:00:00Z", response.CharacterProgress.Progress[0].Start)
+	assert.Equal(1500000, response.CharacterProgress.Progress[0].ExperienceChange)
+	assert.Equal("2026-01-12T00:00:00Z", response.CharacterProgress.Progress[1].Start)
+
+	// the level of the highscores is used without snapshots of the character pages
+	assert.Nil(TibiaDataHistory.Save("experience", "Bobeek", TibiaDataSnapshot{Timestamp: "2026-01-05T11:00:00Z", Data: json.RawMessage(`{"level":200,"value":100}`)}))
+	assert.Nil(TibiaDataHistory.Save("experience", "Bobeek", TibiaDataSnapshot{Timestamp: "2026-01-06T11:00:00Z", Data: json.RawMessage(`{"level":201,"value":200}`)}))
+	response, err = TibiaCharactersProgressImpl("Bobeek", TibiaCharactersProgressDay, from, to)
+	assert.Nil(err)
+	assert.Equal(201, response.CharacterProgress.Level)
+	assert.Len(response.CharacterProgress.LevelChanges, 1)
+	assert.Equal(1, response.CharacterProgress.Progress[1].LevelChange)
+	assert.Equal(100, response.CharacterProgress.Progress[1].ExperienceChange)
+
+	// experience taken before the first character page has the level of that page
+	for _, snapshot := range []struct {
+		kind, timestamp string
+		data            interface{}
+	}{
+		{"experience", "2026-01-04T11:00:00Z", Highscore{Name: "Goraca", Level: 49, Value: 1000}},
+		{"character", "2026-01-05T10:00:00Z", Character{CharacterInfo: CharacterInfo{Name: "Goraca", Level: 50}}},
+		{"experience", "2026-01-05T11:00:00Z", Highscore{Name: "Goraca", Level: 50, Value: 2000}},
+	} {
+		raw, err := json.Marshal(snapshot.data)
+		assert.Nil(err)
+		assert.Nil(TibiaDataHistory.Save(snapshot.kind, "Goraca", TibiaDataSnapshot{Timestamp: snapshot.timestamp, Data: raw}))
+	}
+	response, err = TibiaCharactersProgressImpl("Goraca", TibiaCharactersProgressDay, from, to)
+	assert.Nil(err)
+	assert.Equal(50, response.CharacterProgress.Level)
+	assert.Empty(response.CharacterProgress.LevelChanges)
+	assert.Equal([]CharacterProgressPeriod{
+		{Start: "2026-01-04T00:00:00Z", LevelStart: 50, LevelEnd: 50, ExperienceStart: 1000, ExperienceEnd: 1000},
+		{Start: "2026-01-05T00:00:00Z", LevelStart: 50, LevelEnd: 50, ExperienceStart: 1000, ExperienceEnd: 2000, ExperienceChange: 1000},
+	}, response.CharacterProgress.Progress)
+}
+
+func TestCharactersProgressHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	assert := assert.New(t)
+
+	history := TibiaDataHistory
+	var err error
+	TibiaDataHistory, err = NewTibiaDataHistoryStore(t.TempDir(), time.Hour, 0)
+	assert.Nil(err)
+	defer func() { TibiaDataHistory = history }()
+
+	// characters and experience highscores are recorded
+	now := time.Now()
+	TibiaDataHistory.Record("TibiaCharactersCharacter", CharacterResponse{Character: Character{CharacterInfo: CharacterInfo{Name: "Trollefar", Level: 100}}}, now)
+	TibiaDataHistory.Record("TibiaHighscores", HighscoresResponse{Highscores: Highscores{
+		Category:      "experience",
+		Vocation:      "all",
+		HighscoreList: []Highscore{{Name: "Trollefar", Level: 100, Value: 15000000}},
+	}}, now)
+
+	assert.Eventually(func() bool {
+		response, _ := TibiaCharactersProgressImpl("Trollefar", TibiaCharactersProgressDay, now.Add(-time.Hour), now)
+		return response.CharacterProgress.Snapshots == 2
+	}, time.Second, 10*time.Millisecond)
+
+	request := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+		c.Params = []gin.Param{{Key: "name", Value: "Trollefar"}}
+		tibiaCharactersProgress(c)
+		return w
+	}
+
+	w := request("/v4/character/Trollefar/progress")
+	assert.Equal(http.StatusOK, w.Code)
+
+	var response CharacterProgressResponse
+	assert.Nil(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(100, response.CharacterProgress.Level)
+	assert.Equal(15000000, response.CharacterProgress.Experience)
+
+	w = request("/v4/character/Trollefar/progress?period=month")
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Contains(w.Body.String(), `"error":9005`)
+
+	w = request("/v4/character/Trollefar/progress?from=2026-02-01&to=2026-01-01")
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Contains(w.Body.String(), `"error":9004`)
+
+	// the history must be enabled
+	TibiaDataHistory = nil
+	w = request("/v4/character/Trollefar/progress")
+	assert.Equal(http.StatusNotFound, w.Code)
+	assert.Contains(w.Body.String(), `"error":9003`)
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"container/list"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TibiaDataCacheEntry holds the content fetched from tibia.com for one upstream request
+type TibiaDataCacheEntry struct {
+	Content    string    `json:"content"`     // The BoxContentHTML received from tibia.com.
+	Created    time.Time `json:"created"`     // The time the content was fetched.
+	Expires    time.Time `json:"expires"`     // The time the content should be fetched again.
+	StaleUntil time.Time `json:"stale_until"` // The time the content should not be served anymore, even if tibia.com is failing.
+}
+
+// retainUntil func - returns the time the entry can be removed from the cache
+func (e TibiaDataCacheEntry) retainUntil() time.Time {
+	if e.StaleUntil.After(e.Expires) {
+		return e.StaleUntil
+	}
+	return e.Expires
+}
+
+// Cache is a store of upstream responses used by tibiaDataRequestHandler
+type Cache interface {
+	// Get returns the entry of the key until it can be removed (it may have expired already)
+	Get(key string, now time.Time) (TibiaDataCacheEntry, bool, error)
+	// Set stores the entry under the key until it can be removed
+	Set(key string, entry TibiaDataCacheEntry) error
+	// Purge removes all entries that can be removed
+	Purge(now time.Time) error
+}
+
+// TibiaDataCacheMemory is an in-memory store of upstream responses evicting the least recently used entry
+type TibiaDataCacheMemory struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	lru        *list.List // front is the most recently used
+}
+
+// tibiaDataCacheMemoryItem is an element of the lru list
+type tibiaDataCacheMemoryItem struct {
+	key   string
+	entry TibiaDataCacheEntry
+}
+
+var (
+	// TibiaDataCache - the cache used by tibiaDataRequestHandler
+	TibiaDataCache Cache = NewTibiaDataCacheMemory(TibiaDataCacheMaxEntries)
+
+	// TibiaDataCacheTTLs - time to live of the cached content per handler name
+	// can be overridden by env TIBIADATA_CACHE_TTLS (e.g. TibiaWorldsWorld=30s,TibiaNews=1h)
+	TibiaDataCacheTTLs = map[string]time.Duration{
+		"TibiaWorldsOverview":          time.Minute,
+		"TibiaWorldsWorld":             time.Minute,
+		"TibiaCharactersCharacter":     time.Minute,
+		"TibiaBoostableBosses":         10 * time.Minute,
+		"TibiaAchievementsOverview":    6 * time.Hour,
+		"TibiaAchievementsAchievement": 6 * time.Hour,
+		"TibiaCreaturesOverview":       10 * time.Minute, // contains the boosted creature
+		"TibiaCreaturesCreature":       6 * time.Hour,
+		"TibiaSpellsOverview":          6 * time.Hour,
+		"TibiaSpellsSpell":             6 * time.Hour,
+		"TibiaFansites":                6 * time.Hour,
+	}
+
+	// TibiaDataHighscoresUpdateInterval - how often tibia.com updates the highscores and leaderboards
+	TibiaDataHighscoresUpdateInterval = time.Hour
+)
+
+// NewTibiaDataCacheMemory func - returns an empty in-memory cache holding at most maxEntries entries
+func NewTibiaDataCacheMemory(maxEntries int) *TibiaDataCacheMemory {
+	return &TibiaDataCacheMemory{
+		maxEntries: max(maxEntries, 1),
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// Get func - returns the entry of the key until it can be removed
+func (s *TibiaDataCacheMemory) Get(key string, now time.Time) (TibiaDataCacheEntry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.entries[key]
+	if !ok {
+		return TibiaDataCacheEntry{}, false, nil
+	}
+
+	item := element.Value.(*tibiaDataCacheMemoryItem)
+	if !now.Before(item.entry.retainUntil()) {
+		s.remove(element)
+		return TibiaDataCacheEntry{}, false, nil
+	}
+
+	s.lru.MoveToFront(element)
+	return item.entry, true, nil
+}
+
+// Set func - stores the entry under the key
+func (s *TibiaDataCacheMemory) Set(key string, entry TibiaDataCacheEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if element, ok := s.entries[key]; ok {
+		element.Value.(*tibiaDataCacheMemoryItem).entry = entry
+		s.lru.MoveToFront(element)
+		return nil
+	}
+
+	s.entries[key] = s.lru.PushFront(&tibiaDataCacheMemoryItem{key: key, entry: entry})
+
+	// evicting the least recently used entries
+	for s.lru.Len() > s.maxEntries {
+		s.remove(s.lru.Back())
+	}
+
+	return nil
+}
+
+// Purge func - removes all entries that can be removed
+func (s *TibiaDataCacheMemory) Purge(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, element := range s.entries {
+		if !now.Before(element.Value.(*tibiaDataCacheMemoryItem).entry.retainUntil()) {
+			s.remove(element)
+		}
+	}
+
+	return nil
+}
+
+// remove func - removes the element from the list and the map (the lock must be held)
+func (s *TibiaDataCacheMemory) remove(element *list.Element) {
+	s.lru.Remove(element)
+	delete(s.entries, element.Value.(*tibiaDataCacheMemoryItem).key)
+}
+
+// TibiaDataCacheJanitor func - purges expired entries of the cache every interval
+func TibiaDataCacheJanitor(interval time.Duration) {
+	for now := range time.Tick(interval) {
+		if err := TibiaDataCache.Purge(now); err != nil {
+			log.Printf("[warning] TibiaDataCacheJanitor: %s", err)
+		}
+		TibiaDataStale.Purge(now, time.Hour)
+	}
+}
+
+// TibiaDataCacheNew func - returns the cache of the backend (memory, disk or redis)
+// the settings of the disk and redis backends are read from env
+func TibiaDataCacheNew(backend string) (Cache, error) {
+	switch backend {
+	case "memory":
+		return NewTibiaDataCacheMemory(TibiaDataCacheMaxEntries), nil
+	case "disk":
+		return NewTibiaDataCacheDisk(getEnv("TIBIADATA_CACHE_DIR", filepath.Join(os.TempDir(), "tibiadata-cache")))
+	case "redis":
+		return NewTibiaDataCacheRESP(
+			getEnv("TIBIADATA_CACHE_REDIS_ADDR", "localhost:6379"),
+			getEnv("TIBIADATA_CACHE_REDIS_PASSWORD", ""),
+			getEnvAsInt("TIBIADATA_CACHE_REDIS_DB", 0),
+		), nil
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q", backend)
+	}
+}
+
+// tibiaDataCacheKey func - returns the key of an upstream request
+func tibiaDataCacheKey(tibiaDataRequest TibiaDataRequestStruct) string {
+	key := tibiaDataRequest.Method + " " + tibiaDataRequest.URL
+
+	if len(tibiaDataRequest.FormData) > 0 {
+		formData := url.Values{}
+		for k, v := range tibiaDataRequest.FormData {
+			formData.Set(k, v)
+		}
+
+		// Encode sorts by key, so the order of the map does not matter
+		key += " " + formData.Encode()
+	}
+
+	return key
+}
+
+// tibiaDataCacheTTL func - returns how long the response of the handler can be cached
+func tibiaDataCacheTTL(handlerName string, jsonData interface{}) time.Duration {
+	if !TibiaDataCacheEnabled {
+		return 0
+	}
+
+	// highscores and leaderboards can be cached until tibia.com updates them
+	switch data := jsonData.(type) {
+	case HighscoresResponse:
+		return max(TibiaDataHighscoresUpdateInterval-time.Duration(data.Highscores.HighscoreAge)*time.Minute, time.Minute)
+	case LeaderboardResponse:
+		return max(TibiaDataHighscoresUpdateInterval-time.Duration(data.Leaderboard.LeaderboardAge)*time.Minute, time.Minute)
+	}
+
+	if ttl, ok := TibiaDataCacheTTLs[handlerName]; ok {
+		return ttl
+	}
+
+	return TibiaDataCacheDefaultTTL
+}
+
+// tibiaDataCacheHeaders func - sets the Cache-Control and Age headers of the response
+func tibiaDataCacheHeaders(c *gin.Context, entry TibiaDataCacheEntry, now time.Time) {
+	if entry.Expires.IsZero() {
+		c.Header("Cache-Control", "no-cache")
+		return
+	}
+
+	// stale content is not cached any further
+	c.Header("Cache-Control", "public, max-age="+strconv.Itoa(max(int(entry.Expires.Sub(now).Seconds()), 0)))
+	c.Header("Age", strconv.Itoa(int(now.Sub(entry.Created).Seconds())))
+}
+
+// tibiaDataCacheParseTTLs func - parses a list of handler names and durations (e.g. TibiaWorldsWorld=30s,TibiaNews=1h)
+func tibiaDataCacheParseTTLs(data string) (map[string]time.Duration, error) {
+	ttls := make(map[string]time.Duration)
+
+	for _, pair := range strings.Split(data, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		handlerName, value, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("missing '=' in %q", pair)
+		}
+
+		ttl, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, err
+		}
+
+		ttls[strings.TrimSpace(handlerName)] = ttl
+	}
+
+	return ttls, nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TibiaDataCacheDisk is a store of upstream responses keeping one file per key in a directory
+// the files survive restarts and can be shared by replicas mounting the same volume
+type TibiaDataCacheDisk struct {
+	dir string
+}
+
+// NewTibiaDataCacheDisk func - returns a cache storing its entries in dir (created if missing)
+func NewTibiaDataCacheDisk(dir string) (*TibiaDataCacheDisk, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &TibiaDataCacheDisk{dir: dir}, nil
+}
+
+// Get func - returns the entry of the key until it can be removed
+func (s *TibiaDataCacheDisk) Get(key string, now time.Time) (TibiaDataCacheEntry, bool, error) {
+	return s.read(s.path(key), now)
+}
+
+// Set func - stores the entry under the key
+func (s *TibiaDataCacheDisk) Set(key string, entry TibiaDataCacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	// writing to a temporary file first, so readers never see a partial entry
+	file, err := os.CreateTemp(s.dir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), s.path(key))
+}
+
+// Purge func - removes all entries that can be removed
+func (s *TibiaDataCacheDisk) Purge(now time.Time) error {
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if filepath.Ext(file.Name()) == ".json" {
+			// read removes the file if it can be removed
+			s.read(filepath.Join(s.dir, file.Name()), now)
+		}
+	}
+
+	return nil
+}
+
+// read func - returns the entry of the file until it can be removed
+func (s *TibiaDataCacheDisk) read(path string, now time.Time) (TibiaDataCacheEntry, bool, error) {
+	var entry TibiaDataCacheEntry
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return entry, false, nil
+	} else if err != nil {
+		return entry, false, err
+	}
+
+	if err := json.Unmarshal(data, &entry); err != nil {
+		// a broken file is removed, so it is written again
+		os.Remove(path)
+		return TibiaDataCacheEntry{}, false, err
+	}
+
+	if !now.Before(entry.retainUntil()) {
+		os.Remove(path)
+		return TibiaDataCacheEntry{}, false, nil
+	}
+
+	return entry, true, nil
+}
+
+// path func - returns the file of the key
+// keys contain urls, so they are hashed to get a valid file name
+func (s *TibiaDataCacheDisk) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(hash[:])+".json")
+}
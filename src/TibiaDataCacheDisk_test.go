@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheDisk(t *testing.T) {
+	dir := t.TempDir()
+
+	cache, err := NewTibiaDataCacheDisk(dir)
+	if err != nil {
+		t.Fatalf("cache creation error: %s", err)
+	}
+	testCache(t, cache)
+
+	assert := assert.New(t)
+	now := time.Now()
+
+	// the expired entries were removed by Get
+	files, _ := os.ReadDir(dir)
+	assert.Equal(0, len(files))
+
+	// entries survive a restart
+	cache.Set("https://www.tibia.com/?a=1&b=2", TibiaDataCacheEntry{Content: "c", Created: now, Expires: now.Add(time.Minute)})
+	cache, _ = NewTibiaDataCacheDisk(dir)
+	entry, ok, err := cache.Get("https://www.tibia.com/?a=1&b=2", now)
+	assert.Nil(err)
+	assert.True(ok)
+	assert.Equal("c", entry.Content)
+
+	// broken files are treated as a miss and removed
+	path := cache.path("broken")
+	os.WriteFile(path, []byte("{"), 0o644)
+	_, ok, err = cache.Get("broken", now)
+	assert.NotNil(err)
+	assert.False(ok)
+	_, err = os.Stat(path)
+	assert.True(os.IsNotExist(err))
+
+	// files of other programs are left alone
+	os.WriteFile(filepath.Join(dir, "README"), []byte("hello"), 0o644)
+	assert.Nil(cache.Purge(now.Add(time.Hour)))
+	files, _ = os.ReadDir(dir)
+	assert.Equal(1, len(files))
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TibiaDataCacheRESPPrefix - the prefix of all keys written to the server
+const TibiaDataCacheRESPPrefix = "tibiadata:"
+
+// TibiaDataCacheRESP is a store of upstream responses kept on a server speaking the redis protocol (RESP)
+// so that several replicas of the api share one cache
+type TibiaDataCacheRESP struct {
+	addr     string
+	password string
+	db       int
+	timeout  time.Duration
+
+	conns chan *tibiaDataRESPConn // idle connections
+}
+
+// tibiaDataRESPConn is a connection to the server
+type tibiaDataRESPConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// tibiaDataRESPError is an error reply of the server
+type tibiaDataRESPError string
+
+func (e tibiaDataRESPError) Error() string {
+	return "resp: " + string(e)
+}
+
+// NewTibiaDataCacheRESP func - returns a cache using the server at addr
+// connections are opened when needed, so the server does not have to be up yet
+func NewTibiaDataCacheRESP(addr, password string, db int) *TibiaDataCacheRESP {
+	return &TibiaDataCacheRESP{
+		addr:     addr,
+		password: password,
+		db:       db,
+		timeout:  2 * time.Second,
+		conns:    make(chan *tibiaDataRESPConn, 10),
+	}
+}
+
+// Get func - returns the entry of the key until it can be removed
+func (s *TibiaDataCacheRESP) Get(key string, now time.Time) (TibiaDataCacheEntry, bool, error) {
+	var entry TibiaDataCacheEntry
+
+	reply, err := s.do("GET", TibiaDataCacheRESPPrefix+key)
+	if err != nil || reply == nil {
+		return entry, false, err
+	}
+
+	data, ok := reply.(string)
+	if !ok {
+		return entry, false, fmt.Errorf("resp: unexpected reply %T to GET", reply)
+	}
+
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		return TibiaDataCacheEntry{}, false, err
+	}
+
+	// the server expires the key as well, but the clocks may differ slightly
+	if !now.Before(entry.retainUntil()) {
+		return TibiaDataCacheEntry{}, false, nil
+	}
+
+	return entry, true, nil
+}
+
+// Set func - stores the entry under the key until it can be removed
+func (s *TibiaDataCacheRESP) Set(key string, entry TibiaDataCacheEntry) error {
+	ttl := time.Until(entry.retainUntil()).Milliseconds()
+	if ttl <= 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.do("SET", TibiaDataCacheRESPPrefix+key, string(data), "PX", strconv.FormatInt(ttl, 10))
+	return err
+}
+
+// Purge func - does nothing, since the server expires the keys itself
+func (s *TibiaDataCacheRESP) Purge(now time.Time) error {
+	return nil
+}
+
+// do func - sends a command to the server and returns its reply
+func (s *TibiaDataCacheRESP) do(args ...string) (interface{}, error) {
+	conn, err := s.get()
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := conn.do(s.timeout, args...)
+	if err != nil {
+		// an error reply leaves the connection usable, anything else does not
+		var respErr tibiaDataRESPError
+		if !errors.As(err, &respErr) {
+			conn.conn.Close()
+			return nil, err
+		}
+	}
+
+	s.put(conn)
+	return reply, err
+}
+
+// get func - returns an idle connection or opens a new one
+func (s *TibiaDataCacheRESP) get() (*tibiaDataRESPConn, error) {
+	select {
+	case conn := <-s.conns:
+		return conn, nil
+	default:
+	}
+
+	netConn, err := net.DialTimeout("tcp", s.addr, s.timeout)
+	if err != nil {
+		return nil, err
+	}
+	conn := &tibiaDataRESPConn{conn: netConn, reader: bufio.NewReader(netConn)}
+
+	if s.password != "" {
+		if _, err := conn.do(s.timeout, "AUTH", s.password); err != nil {
+			netConn.Close()
+			return nil, err
+		}
+	}
+	if s.db != 0 {
+		if _, err := conn.do(s.timeout, "SELECT", strconv.Itoa(s.db)); err != nil {
+			netConn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+// put func - keeps the connection for the next command or closes it if enough are idle
+func (s *TibiaDataCacheRESP) put(conn *tibiaDataRESPConn) {
+	select {
+	case s.conns <- conn:
+	default:
+		conn.conn.Close()
+	}
+}
+
+// do func - writes a command as an array of bulk strings and reads the reply
+func (c *tibiaDataRESPConn) do(timeout time.Duration, args ...string) (interface{}, error) {
+	if err := c.conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+
+	var command strings.Builder
+	command.WriteString("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		command.WriteString("$" + strconv.Itoa(len(arg)) + "\r\n" + arg + "\r\n")
+	}
+
+	if _, err := io.WriteString(c.conn, command.String()); err != nil {
+		return nil, err
+	}
+
+	return tibiaDataRESPRead(c.reader)
+}
+
+// tibiaDataRESPRead func - reads one reply
+// simple strings and bulk strings are returned as string, integers as int64,
+// arrays as []interface{}, null replies as nil and error replies as tibiaDataRESPError
+func tibiaDataRESPRead(reader *bufio.Reader) (interface{}, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || !strings.HasSuffix(line, "\r\n") {
+		return nil, fmt.Errorf("resp: malformed line %q", line)
+	}
+	line = line[:len(line)-2]
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, tibiaDataRESPError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if length < 0 {
+			return nil, nil
+		}
+
+		data := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		return string(data[:length]), nil
+	case '*':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if length < 0 {
+			return nil, nil
+		}
+
+		// error replies inside the array are kept as elements, so the rest of it is still read
+		array := make([]interface{}, length)
+		for i := range array {
+			element, err := tibiaDataRESPRead(reader)
+			var respErr tibiaDataRESPError
+			if errors.As(err, &respErr) {
+				element = respErr
+			} else if err != nil {
+				return nil, err
+			}
+			array[i] = element
+		}
+		return array, nil
+	default:
+		return nil, fmt.Errorf("resp: unknown reply type %q", line[0])
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testRESPServer is a stand-in for a redis server supporting the commands used by the cache
+type testRESPServer struct {
+	listener net.Listener
+	password string
+
+	mu       sync.Mutex
+	data     map[string]string
+	commands []string
+}
+
+func newTestRESPServer(t *testing.T, password string) *testRESPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen error: %s", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	server := &testRESPServer{listener: listener, password: password, data: make(map[string]string)}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+
+	return server
+}
+
+func (s *testRESPServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	authenticated := s.password == ""
+
+	for {
+		reply, err := tibiaDataRESPRead(reader)
+		if err != nil {
+			return
+		}
+
+		var args []string
+		for _, arg := range reply.([]interface{}) {
+			args = append(args, arg.(string))
+		}
+
+		s.mu.Lock()
+		s.commands = append(s.commands, strings.Join(args, " "))
+
+		var response string
+		switch {
+		case args[0] == "AUTH":
+			authenticated = args[1] == s.password
+			response = "+OK\r\n"
+			if !authenticated {
+				response = "-WRONGPASS invalid password\r\n"
+			}
+		case !authenticated:
+			response = "-NOAUTH Authentication required.\r\n"
+		case args[0] == "SELECT":
+			response = "+OK\r\n"
+		case args[0] == "SET":
+			s.data[args[1]] = args[2]
+			response = "+OK\r\n"
+		case args[0] == "GET":
+			value, ok := s.data[args[1]]
+			response = "$-1\r\n"
+			if ok {
+				response = "$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n"
+			}
+		default:
+			response = "-ERR unknown command '" + args[0] + "'\r\n"
+		}
+		s.mu.Unlock()
+
+		conn.Write([]byte(response))
+	}
+}
+
+func TestCacheRESP(t *testing.T) {
+	server := newTestRESPServer(t, "secret")
+	testCache(t, NewTibiaDataCacheRESP(server.listener.Addr().String(), "secret", 2))
+
+	assert := assert.New(t)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	// the connection is authenticated and reused for all commands
+	assert.Equal("AUTH secret", server.commands[0])
+	assert.Equal("SELECT 2", server.commands[1])
+	assert.Equal(1, strings.Count(strings.Join(server.commands, "\n"), "AUTH"))
+
+	// expired entries are not sent to the server and keys are prefixed
+	assert.Contains(server.data, "tibiadata:fresh")
+	assert.NotContains(server.data, "tibiadata:expired")
+	assert.Contains(server.commands[2], "PX ")
+}
+
+func TestCacheRESPErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	server := newTestRESPServer(t, "secret")
+	now := time.Now()
+
+	// a wrong password is reported on every command
+	cache := NewTibiaDataCacheRESP(server.listener.Addr().String(), "wrong", 0)
+	_, ok, err := cache.Get("key", now)
+	assert.False(ok)
+	assert.EqualError(err, "resp: WRONGPASS invalid password")
+
+	// an unreachable server is reported as well
+	addr := server.listener.Addr().String()
+	server.listener.Close()
+	cache = NewTibiaDataCacheRESP(addr, "", 0)
+	assert.NotNil(cache.Set("key", TibiaDataCacheEntry{Expires: now.Add(time.Minute)}))
+}
+
+func TestCacheRESPRead(t *testing.T) {
+	assert := assert.New(t)
+
+	reply, err := tibiaDataRESPRead(bufio.NewReader(strings.NewReader("*3\r\n:42\r\n$-1\r\n-ERR nope\r\n")))
+	assert.Nil(err)
+	assert.Equal([]interface{}{int64(42), nil, tibiaDataRESPError("ERR nope")}, reply)
+
+	_, err = tibiaDataRESPRead(bufio.NewReader(strings.NewReader("?\r\n")))
+	assert.NotNil(err)
+
+	_, err = tibiaDataRESPRead(bufio.NewReader(strings.NewReader("$5\r\nab")))
+	assert.NotNil(err)
+}
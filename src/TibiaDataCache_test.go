@@ -0,0 +1,188 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/static"
+)
+
+// testCache checks the behaviour all cache backends have in common
+func testCache(t *testing.T, cache Cache) {
+	assert := assert.New(t)
+
+	now := time.Now()
+
+	assert.Nil(cache.Set("fresh", TibiaDataCacheEntry{Content: "a", Created: now, Expires: now.Add(time.Minute)}))
+	assert.Nil(cache.Set("expired", TibiaDataCacheEntry{Content: "b", Created: now.Add(-2 * time.Minute), Expires: now.Add(-time.Minute)}))
+	assert.Nil(cache.Set("stale", TibiaDataCacheEntry{Content: "c", Created: now.Add(-2 * time.Minute), Expires: now.Add(-time.Minute), StaleUntil: now.Add(time.Minute)}))
+
+	entry, ok, err := cache.Get("fresh", now)
+	assert.Nil(err)
+	assert.True(ok)
+	assert.Equal("a", entry.Content)
+	assert.True(now.Equal(entry.Created))
+
+	_, ok, err = cache.Get("expired", now)
+	assert.Nil(err)
+	assert.False(ok)
+
+	_, ok, err = cache.Get("missing", now)
+	assert.Nil(err)
+	assert.False(ok)
+
+	// expired entries are kept while they may be served stale
+	entry, ok, err = cache.Get("stale", now)
+	assert.Nil(err)
+	assert.True(ok)
+	assert.Equal("c", entry.Content)
+
+	// the fresh and stale entries expire as well
+	_, ok, err = cache.Get("fresh", now.Add(time.Minute))
+	assert.Nil(err)
+	assert.False(ok)
+	_, ok, err = cache.Get("stale", now.Add(time.Minute))
+	assert.Nil(err)
+	assert.False(ok)
+
+	assert.Nil(cache.Purge(now))
+}
+
+func TestCacheMemory(t *testing.T) {
+	testCache(t, NewTibiaDataCacheMemory(10))
+
+	assert := assert.New(t)
+
+	cache := NewTibiaDataCacheMemory(2)
+	now := time.Now()
+	expires := now.Add(time.Minute)
+
+	cache.Set("a", TibiaDataCacheEntry{Content: "a", Expires: expires})
+	cache.Set("b", TibiaDataCacheEntry{Content: "b", Expires: expires})
+	cache.Get("a", now)
+	cache.Set("c", TibiaDataCacheEntry{Content: "c", Expires: expires})
+
+	// b was the least recently used entry
+	_, ok, _ := cache.Get("b", now)
+	assert.False(ok)
+	_, ok, _ = cache.Get("a", now)
+	assert.True(ok)
+	_, ok, _ = cache.Get("c", now)
+	assert.True(ok)
+
+	// a is evicted by d, which is expired and purged
+	cache.Set("d", TibiaDataCacheEntry{Content: "d", Expires: now})
+	cache.Purge(now)
+	assert.Equal(1, cache.lru.Len())
+	assert.Equal(1, len(cache.entries))
+	_, ok, _ = cache.Get("c", now)
+	assert.True(ok)
+}
+
+func TestCacheNew(t *testing.T) {
+	assert := assert.New(t)
+
+	cache, err := TibiaDataCacheNew("memory")
+	assert.Nil(err)
+	assert.IsType(&TibiaDataCacheMemory{}, cache)
+
+	t.Setenv("TIBIADATA_CACHE_DIR", t.TempDir())
+	cache, err = TibiaDataCacheNew("disk")
+	assert.Nil(err)
+	assert.IsType(&TibiaDataCacheDisk{}, cache)
+
+	cache, err = TibiaDataCacheNew("redis")
+	assert.Nil(err)
+	assert.IsType(&TibiaDataCacheRESP{}, cache)
+
+	_, err = TibiaDataCacheNew("memcached")
+	assert.NotNil(err)
+}
+
+func TestCacheKey(t *testing.T) {
+	assert := assert.New(t)
+
+	get := TibiaDataRequestStruct{Method: resty.MethodGet, URL: "https://www.tibia.com/news/?subtopic=newsarchive"}
+	post1 := TibiaDataRequestStruct{Method: resty.MethodPost, URL: get.URL, FormData: map[string]string{"filter_begin_day": "1", "filter_cipsoft": "cipsoft"}}
+	post2 := TibiaDataRequestStruct{Method: resty.MethodPost, URL: get.URL, FormData: map[string]string{"filter_cipsoft": "cipsoft", "filter_begin_day": "1"}}
+	post3 := TibiaDataRequestStruct{Method: resty.MethodPost, URL: get.URL, FormData: map[string]string{"filter_begin_day": "2", "filter_cipsoft": "cipsoft"}}
+
+	assert.Equal("GET https://www.tibia.com/news/?subtopic=newsarchive", tibiaDataCacheKey(get))
+	assert.Equal(tibiaDataCacheKey(post1), tibiaDataCacheKey(post2))
+	assert.NotEqual(tibiaDataCacheKey(post1), tibiaDataCacheKey(post3))
+	assert.NotEqual(tibiaDataCacheKey(get), tibiaDataCacheKey(post1))
+}
+
+func TestCacheTTL(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(time.Minute, tibiaDataCacheTTL("TibiaWorldsWorld", WorldResponse{}))
+	assert.Equal(6*time.Hour, tibiaDataCacheTTL("TibiaSpellsSpell", SpellInformationResponse{}))
+	assert.Equal(TibiaDataCacheDefaultTTL, tibiaDataCacheTTL("TibiaGuildsGuild", GuildResponse{}))
+
+	// highscores are cached until the next update
+	assert.Equal(48*time.Minute, tibiaDataCacheTTL("TibiaHighscores", HighscoresResponse{Highscores: Highscores{HighscoreAge: 12}}))
+	assert.Equal(time.Minute, tibiaDataCacheTTL("TibiaHighscores", HighscoresResponse{Highscores: Highscores{HighscoreAge: 75}}))
+	assert.Equal(53*time.Minute, tibiaDataCacheTTL("TibiaLeaderboards", LeaderboardResponse{Leaderboard: Leaderboard{LeaderboardAge: 7}}))
+
+	TibiaDataCacheEnabled = false
+	defer func() { TibiaDataCacheEnabled = true }()
+	assert.Equal(time.Duration(0), tibiaDataCacheTTL("TibiaWorldsWorld", WorldResponse{}))
+}
+
+func TestCacheParseTTLs(t *testing.T) {
+	assert := assert.New(t)
+
+	ttls, err := tibiaDataCacheParseTTLs("TibiaWorldsWorld=30s, TibiaNews=1h,")
+	assert.Nil(err)
+	assert.Equal(map[string]time.Duration{"TibiaWorldsWorld": 30 * time.Second, "TibiaNews": time.Hour}, ttls)
+
+	_, err = tibiaDataCacheParseTTLs("TibiaWorldsWorld")
+	assert.NotNil(err)
+
+	_, err = tibiaDataCacheParseTTLs("TibiaWorldsWorld=soon")
+	assert.NotNil(err)
+}
+
+func TestCacheRequestHandler(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/fansites/all.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	assert := assert.New(t)
+
+	// the content is served from the cache, so tibia.com is never requested
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method: resty.MethodGet,
+		URL:    "https://www.tibia.com/community/?subtopic=fansites",
+	}
+	now := time.Now()
+	assert.Nil(TibiaDataCache.Set(tibiaDataCacheKey(tibiadataRequest), TibiaDataCacheEntry{
+		Content: string(data),
+		Created: now.Add(-10 * time.Second),
+		Expires: now.Add(time.Hour),
+	}))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	tibiaFansites(c)
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Contains(w.Header().Get("Cache-Control"), "public, max-age=")
+	assert.Contains([]string{"10", "11"}, w.Header().Get("Age"))
+	assert.Contains(w.Body.String(), `"fansites"`)
+}
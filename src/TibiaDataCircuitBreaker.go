@@ -0,0 +1,150 @@
+package main
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+// states of the circuit breaker
+const (
+	TibiaDataCircuitClosed   = "closed"    // Requests are sent to tibia.com.
+	TibiaDataCircuitOpen     = "open"      // Requests are rejected until the cooldown has passed.
+	TibiaDataCircuitHalfOpen = "half-open" // One request is sent to find out whether tibia.com recovered.
+)
+
+// TibiaDataCircuitBreakerStats stores the state of the circuit breaker
+type TibiaDataCircuitBreakerStats struct {
+	State               string `json:"state"`                // The state of the circuit breaker (closed, open or half-open).
+	ConsecutiveFailures int    `json:"consecutive_failures"` // The number of failed requests since the last successful one.
+	Openings            int    `json:"openings"`             // The number of times the circuit opened since tibia.com last recovered.
+	OpenUntil           string `json:"open_until,omitempty"` // The time a request is sent to tibia.com again.
+	LastError           string `json:"last_error,omitempty"` // The error of the last failed request.
+}
+
+// TibiaDataCircuitBreaker stops requests to tibia.com after repeated failures
+// the time it stays open grows exponentially with every opening and is jittered,
+// so several replicas do not hit tibia.com at the same moment
+type TibiaDataCircuitBreaker struct {
+	threshold   int
+	cooldown    time.Duration
+	maxCooldown time.Duration
+
+	mu        sync.Mutex
+	state     string
+	failures  int
+	openings  int
+	openUntil time.Time
+	trial     bool // whether the request of the half-open state is in flight
+	lastError string
+}
+
+// TibiaDataBreaker - the circuit breaker used by TibiaDataHTMLDataCollector
+var TibiaDataBreaker = NewTibiaDataCircuitBreaker(5, 10*time.Second, 5*time.Minute)
+
+// NewTibiaDataCircuitBreaker func - returns a closed circuit breaker opening after threshold consecutive failures
+func NewTibiaDataCircuitBreaker(threshold int, cooldown, maxCooldown time.Duration) *TibiaDataCircuitBreaker {
+	return &TibiaDataCircuitBreaker{
+		threshold:   max(threshold, 1),
+		cooldown:    cooldown,
+		maxCooldown: max(maxCooldown, cooldown),
+		state:       TibiaDataCircuitClosed,
+	}
+}
+
+// Allow func - returns TibiaDataUnavailableError if no request should be sent to tibia.com
+// every allowed request must be followed by a call of Success, Failure or Cancel
+func (b *TibiaDataCircuitBreaker) Allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case TibiaDataCircuitOpen:
+		if now.Before(b.openUntil) {
+			return TibiaDataUnavailableError{Err: validation.ErrorUpstreamCircuitOpen, RetryAfter: b.openUntil.Sub(now)}
+		}
+
+		// the cooldown has passed, so one request may find out whether tibia.com recovered
+		b.state = TibiaDataCircuitHalfOpen
+		b.trial = true
+		return nil
+	case TibiaDataCircuitHalfOpen:
+		if b.trial {
+			return TibiaDataUnavailableError{Err: validation.ErrorUpstreamCircuitOpen, RetryAfter: b.cooldown}
+		}
+
+		b.trial = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Cancel func - releases an allowed request that was not sent after all or whose outcome is not caused by tibia.com
+func (b *TibiaDataCircuitBreaker) Cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == TibiaDataCircuitHalfOpen {
+		b.trial = false
+	}
+}
+
+// Success func - closes the circuit
+func (b *TibiaDataCircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = TibiaDataCircuitClosed
+	b.failures = 0
+	b.openings = 0
+	b.trial = false
+}
+
+// Failure func - counts the failure and opens the circuit once the threshold is reached
+func (b *TibiaDataCircuitBreaker) Failure(now time.Time, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.trial = false
+	if err != nil {
+		b.lastError = err.Error()
+	}
+
+	// requests allowed before the circuit opened do not extend the cooldown
+	if b.state == TibiaDataCircuitOpen || (b.state == TibiaDataCircuitClosed && b.failures < b.threshold) {
+		return
+	}
+
+	// doubling the cooldown with every opening and using a random half of it as jitter
+	cooldown := b.cooldown << min(b.openings, 30)
+	if cooldown <= 0 || cooldown > b.maxCooldown {
+		cooldown = b.maxCooldown
+	}
+	cooldown = cooldown/2 + rand.N(cooldown/2+1)
+
+	b.state = TibiaDataCircuitOpen
+	b.openings++
+	b.openUntil = now.Add(cooldown)
+}
+
+// Stats func - returns the state of the circuit breaker
+func (b *TibiaDataCircuitBreaker) Stats() TibiaDataCircuitBreakerStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := TibiaDataCircuitBreakerStats{
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		Openings:            b.openings,
+		LastError:           b.lastError,
+	}
+	if b.state == TibiaDataCircuitOpen {
+		stats.OpenUntil = b.openUntil.UTC().Format(time.RFC3339)
+	}
+
+	return stats
+}
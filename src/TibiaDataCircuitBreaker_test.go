@@ -0,0 +1,138 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	assert := assert.New(t)
+
+	breaker := NewTibiaDataCircuitBreaker(2, 10*time.Second, 15*time.Second)
+	now := time.Now()
+
+	assert.Nil(breaker.Allow(now))
+	breaker.Failure(now, errors.New("timeout"))
+	assert.Equal(TibiaDataCircuitClosed, breaker.Stats().State)
+	assert.Nil(breaker.Allow(now))
+	breaker.Failure(now, errors.New("timeout"))
+
+	// the circuit opens for half to the full cooldown
+	stats := breaker.Stats()
+	assert.Equal(TibiaDataCircuitOpen, stats.State)
+	assert.Equal(2, stats.ConsecutiveFailures)
+	assert.Equal(1, stats.Openings)
+	assert.Equal("timeout", stats.LastError)
+
+	err := breaker.Allow(now)
+	assert.True(errors.Is(err, validation.ErrorUpstreamCircuitOpen))
+	var unavailableErr TibiaDataUnavailableError
+	assert.True(errors.As(err, &unavailableErr))
+	assert.GreaterOrEqual(unavailableErr.RetryAfter, 5*time.Second)
+	assert.LessOrEqual(unavailableErr.RetryAfter, 10*time.Second)
+
+	// requests allowed before the circuit opened do not extend the cooldown
+	openUntil := breaker.openUntil
+	breaker.Failure(now, errors.New("timeout"))
+	assert.Equal(openUntil, breaker.openUntil)
+
+	// only one request is let through after the cooldown
+	now = now.Add(10 * time.Second)
+	assert.Nil(breaker.Allow(now))
+	assert.Equal(TibiaDataCircuitHalfOpen, breaker.Stats().State)
+	assert.NotNil(breaker.Allow(now))
+
+	// a cancelled request lets the next one through
+	breaker.Cancel()
+	assert.Nil(breaker.Allow(now))
+
+	// the cooldown doubles, but is capped
+	breaker.Failure(now, errors.New("status 503"))
+	assert.Equal(TibiaDataCircuitOpen, breaker.Stats().State)
+	assert.GreaterOrEqual(breaker.openUntil.Sub(now), 7500*time.Millisecond)
+	assert.LessOrEqual(breaker.openUntil.Sub(now), 15*time.Second)
+
+	now = now.Add(15 * time.Second)
+	assert.Nil(breaker.Allow(now))
+	breaker.Success()
+
+	stats = breaker.Stats()
+	assert.Equal(TibiaDataCircuitClosed, stats.State)
+	assert.Equal(0, stats.ConsecutiveFailures)
+	assert.Equal(0, stats.Openings)
+	assert.Empty(stats.OpenUntil)
+}
+
+func TestCircuitBreakerCollector(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		requests atomic.Int32
+		status   atomic.Int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if status.Load() == http.StatusFound {
+			http.Redirect(w, r, "https://maintenance.tibia.com/", http.StatusFound)
+			return
+		}
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	proxies := TibiaDataProxies
+	TibiaDataProxies = NewTibiaDataProxyPool([]string{server.URL + "/"}, TibiaDataProxyRoundRobin, 100, time.Minute, time.Minute)
+	defer func() { TibiaDataProxies = proxies }()
+
+	breaker := TibiaDataBreaker
+	TibiaDataBreaker = NewTibiaDataCircuitBreaker(2, time.Minute, time.Minute)
+	defer func() { TibiaDataBreaker = breaker }()
+
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method: resty.MethodGet,
+		URL:    "https://www.tibia.com/community/?subtopic=worlds",
+	}
+
+	// the maintenance mode is detected and does not count as failure
+	status.Store(http.StatusFound)
+	_, err := TibiaDataHTMLDataCollector(tibiadataRequest)
+	assert.Equal(validation.ErrorMaintenanceMode, err)
+	assert.Equal(int32(1), requests.Load())
+	assert.Equal(0, TibiaDataBreaker.Stats().ConsecutiveFailures)
+
+	// throttled requests are not retried
+	status.Store(http.StatusForbidden)
+	_, err = TibiaDataHTMLDataCollector(tibiadataRequest)
+	assert.Equal(validation.ErrStatusForbidden, err)
+	assert.Equal(int32(2), requests.Load())
+	assert.Equal(1, TibiaDataBreaker.Stats().ConsecutiveFailures)
+
+	// server errors are retried
+	status.Store(http.StatusServiceUnavailable)
+	_, err = TibiaDataHTMLDataCollector(tibiadataRequest)
+	assert.Equal(validation.ErrStatusUnknown, err)
+	assert.Equal(int32(5), requests.Load())
+	assert.Equal(TibiaDataCircuitOpen, TibiaDataBreaker.Stats().State)
+
+	// tibia.com is not requested while the circuit is open
+	_, err = TibiaDataHTMLDataCollector(tibiadataRequest)
+	assert.True(errors.Is(err, validation.ErrorUpstreamCircuitOpen))
+	assert.Equal(int32(5), requests.Load())
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	healthz(c)
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Contains(w.Body.String(), `"state":"open"`)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// TibiaDataCoalescingStats stores how many requests to tibia.com were shared
+type TibiaDataCoalescingStats struct {
+	Requests  int64 `json:"requests"`  // The number of requests handled by the group.
+	Fetches   int64 `json:"fetches"`   // The number of requests that fetched and parsed tibia.com themselves.
+	Coalesced int64 `json:"coalesced"` // The number of requests that waited for the result of an identical request.
+	InFlight  int   `json:"in_flight"` // The number of distinct requests being processed right now.
+}
+
+// tibiaDataCoalescingResult is the shared outcome of one fetch and parse
+type tibiaDataCoalescingResult struct {
+	Entry    TibiaDataCacheEntry // The content of tibia.com.
+	JSONData interface{}         // The parsed response.
+	Err      error               // The error of the fetch or parse.
+	HTTPCode int                 // The http code to use for Err.
+}
+
+// tibiaDataCoalescingCall is a fetch and parse in flight
+type tibiaDataCoalescingCall struct {
+	wg     sync.WaitGroup
+	result tibiaDataCoalescingResult
+}
+
+// TibiaDataCoalescingGroup lets identical requests in flight share one result
+type TibiaDataCoalescingGroup struct {
+	mu    sync.Mutex
+	calls map[string]*tibiaDataCoalescingCall
+
+	requests  atomic.Int64
+	fetches   atomic.Int64
+	coalesced atomic.Int64
+}
+
+// TibiaDataCoalescing - the group used by tibiaDataRequestHandler
+var TibiaDataCoalescing = NewTibiaDataCoalescingGroup()
+
+// NewTibiaDataCoalescingGroup func - returns an empty coalescing group
+func NewTibiaDataCoalescingGroup() *TibiaDataCoalescingGroup {
+	return &TibiaDataCoalescingGroup{
+		calls: make(map[string]*tibiaDataCoalescingCall),
+	}
+}
+
+// Do func - runs fn once for all callers of the same key at the same time
+// the returned bool reports whether the result was shared with another caller
+func (g *TibiaDataCoalescingGroup) Do(key string, fn func() tibiaDataCoalescingResult) (tibiaDataCoalescingResult, bool) {
+	g.requests.Add(1)
+
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		g.coalesced.Add(1)
+
+		call.wg.Wait()
+		return call.result, true
+	}
+
+	// waiting callers get this result if fn panics
+	call := &tibiaDataCoalescingCall{
+		result: tibiaDataCoalescingResult{
+			Err:      errors.New("the request to tibia.com failed unexpectedly"),
+			HTTPCode: http.StatusBadGateway,
+		},
+	}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	g.fetches.Add(1)
+
+	// making sure waiting callers are released even if fn panics
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+
+		call.wg.Done()
+	}()
+
+	call.result = fn()
+
+	return call.result, false
+}
+
+// Stats func - returns the metrics of the group
+func (g *TibiaDataCoalescingGroup) Stats() TibiaDataCoalescingStats {
+	g.mu.Lock()
+	inFlight := len(g.calls)
+	g.mu.Unlock()
+
+	return TibiaDataCoalescingStats{
+		Requests:  g.requests.Load(),
+		Fetches:   g.fetches.Load(),
+		Coalesced: g.coalesced.Load(),
+		InFlight:  inFlight,
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoalescingGroup(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		group   = NewTibiaDataCoalescingGroup()
+		calls   atomic.Int32
+		release = make(chan struct{})
+		wg      sync.WaitGroup
+		results = make([]tibiaDataCoalescingResult, 5)
+		shared  = make([]bool, 5)
+	)
+
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			results[i], shared[i] = group.Do("GET https://www.tibia.com/library/?subtopic=boostablebosses", func() tibiaDataCoalescingResult {
+				calls.Add(1)
+				<-release
+				return tibiaDataCoalescingResult{JSONData: "boosted"}
+			})
+		}(i)
+	}
+
+	// waiting until all callers joined the request in flight
+	for group.Stats().Requests < 5 {
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(1, group.Stats().InFlight)
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(int32(1), calls.Load())
+	for i := range results {
+		assert.Equal("boosted", results[i].JSONData)
+	}
+
+	sharedCount := 0
+	for _, s := range shared {
+		if s {
+			sharedCount++
+		}
+	}
+	assert.Equal(4, sharedCount)
+
+	assert.Equal(TibiaDataCoalescingStats{Requests: 5, Fetches: 1, Coalesced: 4, InFlight: 0}, group.Stats())
+
+	// requests that are not in flight at the same time are not shared
+	result, isShared := group.Do("GET https://www.tibia.com/library/?subtopic=boostablebosses", func() tibiaDataCoalescingResult {
+		return tibiaDataCoalescingResult{JSONData: "next"}
+	})
+	assert.False(isShared)
+	assert.Equal("next", result.JSONData)
+	assert.Equal(int64(2), group.Stats().Fetches)
+}
+
+func TestCoalescingGroupPanic(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		group   = NewTibiaDataCoalescingGroup()
+		started = make(chan struct{})
+		release = make(chan struct{})
+		done    = make(chan tibiaDataCoalescingResult)
+	)
+
+	go func() {
+		defer func() { _ = recover() }()
+
+		group.Do("key", func() tibiaDataCoalescingResult {
+			close(started)
+			<-release
+			panic("parser failed")
+		})
+	}()
+
+	<-started
+	go func() {
+		result, _ := group.Do("key", func() tibiaDataCoalescingResult {
+			return tibiaDataCoalescingResult{JSONData: "not called"}
+		})
+		done <- result
+	}()
+
+	// waiting until the second caller joined the request in flight
+	for group.Stats().Coalesced < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+
+	result := <-done
+	assert.NotNil(result.Err)
+	assert.Nil(result.JSONData)
+	assert.Equal(0, group.Stats().InFlight)
+}
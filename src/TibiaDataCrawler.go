@@ -0,0 +1,413 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+// TibiaDataCrawlerWorldPlaceholder - a job with this placeholder in its path requests the path of every world
+const TibiaDataCrawlerWorldPlaceholder = ":world"
+
+// TibiaDataCrawlerMaxErrors - the errors of the last run kept per job
+const TibiaDataCrawlerMaxErrors = 10
+
+// TibiaDataCrawlerJob is an endpoint of the API requested periodically
+type TibiaDataCrawlerJob struct {
+	Name     string        // The name of the job.
+	Path     string        // The path of the endpoint, e.g. /v4/killstatistics/:world.
+	Interval time.Duration // The time between two runs.
+	Jitter   time.Duration // The random time added to the interval, so replicas do not run at the same moment.
+}
+
+// TibiaDataCrawlerJobStats stores the state of a job
+type TibiaDataCrawlerJobStats struct {
+	Name         string   `json:"name"`                    // The name of the job.
+	Path         string   `json:"path"`                    // The path of the endpoint requested.
+	Interval     string   `json:"interval"`                // The time between two runs.
+	Jitter       string   `json:"jitter"`                  // The random time added to the interval at most.
+	Running      bool     `json:"running"`                 // Whether the job is running right now.
+	Runs         int64    `json:"runs"`                    // The number of completed runs.
+	LastRun      string   `json:"last_run,omitempty"`      // The time the last run started.
+	LastDuration string   `json:"last_duration,omitempty"` // The time the last run took.
+	LastRequests int      `json:"last_requests"`           // The number of requests of the last run.
+	LastFailed   int      `json:"last_failed"`             // The number of failed requests of the last run.
+	LastErrors   []string `json:"last_errors,omitempty"`   // The first errors of the last run.
+	NextRun      string   `json:"next_run,omitempty"`      // The time the next run starts.
+}
+
+// TibiaDataCrawlerStats stores the state of the crawler
+type TibiaDataCrawlerStats struct {
+	Enabled bool                       `json:"enabled"` // Whether the crawler has jobs.
+	Jobs    []TibiaDataCrawlerJobStats `json:"jobs"`    // The state of every job.
+}
+
+// tibiaDataCrawlerConfigFile is the content of the file set by env TIBIADATA_CRAWLER_CONFIG
+type tibiaDataCrawlerConfigFile struct {
+	Jobs []struct {
+		Name     string `json:"name"`
+		Path     string `json:"path"`
+		Interval string `json:"interval"`
+		Jitter   string `json:"jitter"`
+	} `json:"jobs"`
+}
+
+// tibiaDataCrawlerEndpoint is an endpoint of the API the crawler can refresh
+type tibiaDataCrawlerEndpoint struct {
+	Path    string                                                           // The path of the endpoint, e.g. /v4/killstatistics/:world.
+	Request func(params map[string]string) (tibiaDataEndpointRequest, error) // Returns the request of the endpoint by the params of the path.
+}
+
+// tibiaDataCrawlerEndpoints - the endpoints the crawler can refresh, sharing the requests of their handlers
+var tibiaDataCrawlerEndpoints = []tibiaDataCrawlerEndpoint{
+	{Path: "/v4/guild/:name", Request: func(params map[string]string) (tibiaDataEndpointRequest, error) {
+		return tibiaGuildsGuildRequest(params["name"])
+	}},
+	{Path: "/v4/guilds/:world", Request: func(params map[string]string) (tibiaDataEndpointRequest, error) {
+		return tibiaGuildsOverviewRequest(params["world"])
+	}},
+	{Path: "/v4/highscores/:world/:category/:vocation", Request: func(params map[string]string) (tibiaDataEndpointRequest, error) {
+		return tibiaHighscoresRequest(params["world"], params["category"], params["vocation"], "")
+	}},
+	{Path: "/v4/highscores/:world/:category/:vocation/:page", Request: func(params map[string]string) (tibiaDataEndpointRequest, error) {
+		return tibiaHighscoresRequest(params["world"], params["category"], params["vocation"], params["page"])
+	}},
+	{Path: "/v4/killstatistics/:world", Request: func(params map[string]string) (tibiaDataEndpointRequest, error) {
+		return tibiaKillstatisticsRequest(params["world"])
+	}},
+	{Path: "/v4/world/:name", Request: func(params map[string]string) (tibiaDataEndpointRequest, error) {
+		return tibiaWorldsWorldRequest(params["name"])
+	}},
+	{Path: "/v4/worlds", Request: func(params map[string]string) (tibiaDataEndpointRequest, error) {
+		return tibiaWorldsOverviewRequest(), nil
+	}},
+}
+
+// TibiaDataCrawlerScheduler requests endpoints of the API periodically, e.g. to record their history
+// tibia.com is requested even if the content is cached, so every run is recorded,
+// but the requests are coalesced and rate limited like all other requests and served after the requests of users
+type TibiaDataCrawlerScheduler struct {
+	worlds func() ([]string, error)
+
+	mu    sync.Mutex
+	jobs  []TibiaDataCrawlerJob
+	stats map[string]*TibiaDataCrawlerJobStats
+}
+
+// TibiaDataCrawler - the crawler started by the webserver (without jobs by default)
+var TibiaDataCrawler = NewTibiaDataCrawlerScheduler(nil)
+
+// NewTibiaDataCrawlerScheduler func - returns a crawler of the jobs
+func NewTibiaDataCrawlerScheduler(jobs []TibiaDataCrawlerJob) *TibiaDataCrawlerScheduler {
+	crawler := &TibiaDataCrawlerScheduler{
+		worlds: validation.GetWorlds,
+		jobs:   jobs,
+		stats:  make(map[string]*TibiaDataCrawlerJobStats),
+	}
+	for _, job := range jobs {
+		crawler.stats[job.Name] = &TibiaDataCrawlerJobStats{
+			Name:     job.Name,
+			Path:     job.Path,
+			Interval: job.Interval.String(),
+			Jitter:   job.Jitter.String(),
+		}
+	}
+
+	return crawler
+}
+
+// TibiaDataCrawlerJobsFromEnv func - returns the jobs of a list like /v4/worlds=1m,/v4/killstatistics/:world=24h
+// the jitter of every job is the share of its interval
+func TibiaDataCrawlerJobsFromEnv(data string, jitter float64) ([]TibiaDataCrawlerJob, error) {
+	intervals, err := tibiaDataCacheParseTTLs(data)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]TibiaDataCrawlerJob, 0, len(intervals))
+	for path, interval := range intervals {
+		job := TibiaDataCrawlerJob{
+			Name:     path,
+			Path:     path,
+			Interval: interval,
+			Jitter:   time.Duration(float64(interval) * jitter),
+		}
+		if err := job.validate(); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	// the jobs of a map are in random order
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Name < jobs[j].Name })
+
+	return jobs, nil
+}
+
+// TibiaDataCrawlerJobsFromFile func - returns the jobs of a JSON file like {"jobs":[{"name":"worlds","path":"/v4/worlds","interval":"1m","jitter":"5s"}]}
+// jobs without jitter get the share of their interval
+func TibiaDataCrawlerJobsFromFile(path string, jitter float64) ([]TibiaDataCrawlerJob, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config tibiaDataCrawlerConfigFile
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]TibiaDataCrawlerJob, 0, len(config.Jobs))
+	names := make(map[string]bool, len(config.Jobs))
+	for _, jobConfig := range config.Jobs {
+		job := TibiaDataCrawlerJob{
+			Name: jobConfig.Name,
+			Path: jobConfig.Path,
+		}
+		if job.Name == "" {
+			job.Name = job.Path
+		}
+		if names[job.Name] {
+			return nil, fmt.Errorf("job %s: name is not unique", job.Name)
+		}
+		names[job.Name] = true
+
+		job.Interval, err = time.ParseDuration(jobConfig.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("job %s: %w", job.Name, err)
+		}
+
+		job.Jitter = time.Duration(float64(job.Interval) * jitter)
+		if jobConfig.Jitter != "" {
+			job.Jitter, err = time.ParseDuration(jobConfig.Jitter)
+			if err != nil {
+				return nil, fmt.Errorf("job %s: %w", job.Name, err)
+			}
+		}
+
+		if err := job.validate(); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// Start func - runs every job in the background until the context is done
+func (cr *TibiaDataCrawlerScheduler) Start(ctx context.Context) {
+	for _, job := range cr.jobs {
+		go cr.loop(ctx, job)
+	}
+}
+
+// Stats func - returns the state of the crawler
+func (cr *TibiaDataCrawlerScheduler) Stats() TibiaDataCrawlerStats {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	stats := TibiaDataCrawlerStats{
+		Enabled: len(cr.jobs) > 0,
+		Jobs:    make([]TibiaDataCrawlerJobStats, 0, len(cr.jobs)),
+	}
+	for _, job := range cr.jobs {
+		jobStats := *cr.stats[job.Name]
+		jobStats.LastErrors = append([]string(nil), jobStats.LastErrors...)
+		stats.Jobs = append(stats.Jobs, jobStats)
+	}
+
+	return stats
+}
+
+// loop func - runs the job every interval plus jitter, the first run starts after the jitter only
+func (cr *TibiaDataCrawlerScheduler) loop(ctx context.Context, job TibiaDataCrawlerJob) {
+	wait := tibiaDataCrawlerJitter(job.Jitter)
+	for {
+		cr.update(job.Name, func(stats *TibiaDataCrawlerJobStats) {
+			stats.NextRun = time.Now().Add(wait).UTC().Format(time.RFC3339)
+		})
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		cr.run(ctx, job)
+		wait = job.Interval + tibiaDataCrawlerJitter(job.Jitter)
+	}
+}
+
+// run func - requests every path of the job once
+func (cr *TibiaDataCrawlerScheduler) run(ctx context.Context, job TibiaDataCrawlerJob) {
+	start := time.Now()
+	cr.update(job.Name, func(stats *TibiaDataCrawlerJobStats) {
+		stats.Running = true
+		stats.LastRun = start.UTC().Format(time.RFC3339)
+		stats.NextRun = ""
+	})
+
+	var (
+		requests int
+		errs     []string
+	)
+	paths, err := cr.paths(job)
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	for _, path := range paths {
+		if ctx.Err() != nil {
+			break
+		}
+
+		requests++
+		if err := tibiaDataCrawlerRefresh(path); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		log.Printf("[warning] TibiaDataCrawler: job %s failed %d times, first error: %s", job.Name, len(errs), errs[0])
+	}
+
+	cr.update(job.Name, func(stats *TibiaDataCrawlerJobStats) {
+		stats.Running = false
+		stats.Runs++
+		stats.LastDuration = time.Since(start).Round(time.Millisecond).String()
+		stats.LastRequests = requests
+		stats.LastFailed = len(errs)
+		stats.LastErrors = errs[:min(len(errs), TibiaDataCrawlerMaxErrors)]
+	})
+}
+
+// paths func - returns the paths of the job with the world placeholder replaced by every world
+func (cr *TibiaDataCrawlerScheduler) paths(job TibiaDataCrawlerJob) ([]string, error) {
+	if !strings.Contains(job.Path, TibiaDataCrawlerWorldPlaceholder) {
+		return []string{job.Path}, nil
+	}
+
+	worlds, err := cr.worlds()
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(worlds))
+	for _, world := range worlds {
+		paths = append(paths, strings.ReplaceAll(job.Path, TibiaDataCrawlerWorldPlaceholder, url.PathEscape(world)))
+	}
+
+	return paths, nil
+}
+
+// update func - changes the state of the job
+func (cr *TibiaDataCrawlerScheduler) update(name string, fn func(stats *TibiaDataCrawlerJobStats)) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	fn(cr.stats[name])
+}
+
+// validate func - returns an error if the job cannot be run
+func (job TibiaDataCrawlerJob) validate() error {
+	switch {
+	case !strings.HasPrefix(job.Path, "/v4/"):
+		return fmt.Errorf("job %s: path %q is not an endpoint of /v4/", job.Name, job.Path)
+	case tibiaDataCrawlerMatch(job.Path) == nil:
+		return fmt.Errorf("job %s: path %q is not an endpoint the crawler can refresh", job.Name, job.Path)
+	case job.Interval <= 0:
+		return fmt.Errorf("job %s: interval must be positive", job.Name)
+	case job.Jitter < 0:
+		return fmt.Errorf("job %s: jitter must not be negative", job.Name)
+	}
+
+	return nil
+}
+
+// tibiaDataCrawlerRefresh func - requests the content of the path from tibia.com and returns the error of the endpoint
+func tibiaDataCrawlerRefresh(path string) error {
+	endpoint, params := tibiaDataCrawlerMatch(path), map[string]string{}
+	if endpoint == nil {
+		return fmt.Errorf("%s: not an endpoint the crawler can refresh", path)
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range strings.Split(endpoint.Path, "/") {
+		if strings.HasPrefix(segment, ":") {
+			value, err := url.PathUnescape(segments[i])
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			params[segment[1:]] = value
+		}
+	}
+
+	endpointRequest, err := endpoint.Request(params)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	// requests of the crawler should not delay requests of users
+	endpointRequest.Request.Priority = TibiaDataPriorityLow
+	endpointRequest.Request.Refresh = true
+
+	result := tibiaDataRequestFetch(endpointRequest.Request, endpointRequest.Parser, endpointRequest.HandlerName, path)
+	if result.Err != nil {
+		return fmt.Errorf("%s: %w", path, result.Err)
+	}
+
+	return nil
+}
+
+// tibiaDataCrawlerMatch func - returns the endpoint of the path or nil if the crawler cannot refresh it
+func tibiaDataCrawlerMatch(path string) *tibiaDataCrawlerEndpoint {
+	segments := strings.Split(path, "/")
+
+	for i, endpoint := range tibiaDataCrawlerEndpoints {
+		endpointSegments := strings.Split(endpoint.Path, "/")
+		if len(endpointSegments) != len(segments) {
+			continue
+		}
+
+		matches := true
+		for j, segment := range endpointSegments {
+			if !strings.HasPrefix(segment, ":") && segment != segments[j] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return &tibiaDataCrawlerEndpoints[i]
+		}
+	}
+
+	return nil
+}
+
+// tibiaDataCrawlerJitter func - returns a random duration up to the jitter
+func tibiaDataCrawlerJitter(jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return 0
+	}
+
+	return rand.N(jitter)
+}
+
+// crawlerHandler returns the state of the crawler
+func crawlerHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, TibiaDataCrawler.Stats())
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrawlerJobsFromEnv(t *testing.T) {
+	assert := assert.New(t)
+
+	jobs, err := TibiaDataCrawlerJobsFromEnv("/v4/worlds=1m, /v4/killstatistics/:world=24h", 0.1)
+	assert.Nil(err)
+	assert.Equal([]TibiaDataCrawlerJob{
+		{Name: "/v4/killstatistics/:world", Path: "/v4/killstatistics/:world", Interval: 24 * time.Hour, Jitter: 144 * time.Minute},
+		{Name: "/v4/worlds", Path: "/v4/worlds", Interval: time.Minute, Jitter: 6 * time.Second},
+	}, jobs)
+
+	_, err = TibiaDataCrawlerJobsFromEnv("/v4/worlds", 0.1)
+	assert.NotNil(err)
+
+	_, err = TibiaDataCrawlerJobsFromEnv("/debug=1m", 0.1)
+	assert.NotNil(err)
+
+	_, err = TibiaDataCrawlerJobsFromEnv("/v4/worlds=0s", 0.1)
+	assert.NotNil(err)
+
+	_, err = TibiaDataCrawlerJobsFromEnv("/v4/fansites=1h", 0.1)
+	assert.NotNil(err)
+}
+
+func TestCrawlerJobsFromFile(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "crawler.json")
+	assert.Nil(os.WriteFile(path, []byte(`{"jobs":[
+		{"name":"worlds","path":"/v4/worlds","interval":"1m","jitter":"5s"},
+		{"path":"/v4/killstatistics/:world","interval":"24h"}
+	]}`), 0o644))
+
+	jobs, err := TibiaDataCrawlerJobsFromFile(path, 0.5)
+	assert.Nil(err)
+	assert.Equal([]TibiaDataCrawlerJob{
+		{Name: "worlds", Path: "/v4/worlds", Interval: time.Minute, Jitter: 5 * time.Second},
+		{Name: "/v4/killstatistics/:world", Path: "/v4/killstatistics/:world", Interval: 24 * time.Hour, Jitter: 12 * time.Hour},
+	}, jobs)
+
+	for _, config := range []string{
+		`{"jobs":[{"path":"/v4/worlds","interval":"soon"}]}`,
+		`{"jobs":[{"path":"/v4/worlds","interval":"1m","jitter":"-1s"}]}`,
+		`{"jobs":[{"path":"/v4/worlds","interval":"1m"},{"path":"/v4/worlds","interval":"1h"}]}`,
+		`{"jobs":`,
+	} {
+		assert.Nil(os.WriteFile(path, []byte(config), 0o644))
+		_, err = TibiaDataCrawlerJobsFromFile(path, 0.5)
+		assert.NotNil(err, config)
+	}
+
+	_, err = TibiaDataCrawlerJobsFromFile(filepath.Join(t.TempDir(), "missing.json"), 0.5)
+	assert.NotNil(err)
+}
+
+func TestCrawlerRun(t *testing.T) {
+	assert := assert.New(t)
+
+	// tibia.com returns the world of the request
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Query().Get("world"))
+		w.Write([]byte(`<div class="Border_2"><div class="Border_3">` + r.URL.Query().Get("world") + `</div></div>`))
+	}))
+	defer server.Close()
+
+	proxies := TibiaDataProxies
+	TibiaDataProxies = NewTibiaDataProxyPool([]string{server.URL + "/"}, TibiaDataProxyRoundRobin, 100, time.Minute, time.Minute)
+	defer func() { TibiaDataProxies = proxies }()
+
+	cache := TibiaDataCache
+	TibiaDataCache = NewTibiaDataCacheMemory(10)
+	defer func() { TibiaDataCache = cache }()
+
+	endpoints := tibiaDataCrawlerEndpoints
+	tibiaDataCrawlerEndpoints = []tibiaDataCrawlerEndpoint{{Path: "/v4/test/:world", Request: func(params map[string]string) (tibiaDataEndpointRequest, error) {
+		return tibiaDataEndpointRequest{
+			Request: TibiaDataRequestStruct{Method: http.MethodGet, URL: "https://www.tibia.com/test?world=" + TibiaDataQueryEscapeString(params["world"])},
+			Parser: func(BoxContentHTML string) (interface{}, error) {
+				if BoxContentHTML == "Zuna" {
+					return nil, errors.New("tibia.com is down")
+				}
+				return gin.H{"world": BoxContentHTML}, nil
+			},
+			HandlerName: "Test",
+		}, nil
+	}}}
+	defer func() { tibiaDataCrawlerEndpoints = endpoints }()
+
+	crawler := NewTibiaDataCrawlerScheduler([]TibiaDataCrawlerJob{
+		{Name: "test", Path: "/v4/test/:world", Interval: time.Hour},
+	})
+	crawler.worlds = func() ([]string, error) { return []string{"Antica", "Zuna"}, nil }
+
+	crawler.run(context.Background(), crawler.jobs[0])
+	assert.Equal([]string{"Antica", "Zuna"}, requested)
+
+	stats := crawler.Stats()
+	assert.True(stats.Enabled)
+	assert.Len(stats.Jobs, 1)
+	assert.Equal(int64(1), stats.Jobs[0].Runs)
+	assert.False(stats.Jobs[0].Running)
+	assert.Equal(2, stats.Jobs[0].LastRequests)
+	assert.Equal(1, stats.Jobs[0].LastFailed)
+	assert.Equal([]string{"/v4/test/Zuna: tibia.com is down"}, stats.Jobs[0].LastErrors)
+
+	// tibia.com is requested again, even though the content is cached
+	_, cached, err := TibiaDataCache.Get(tibiaDataCacheKey(TibiaDataRequestStruct{Method: http.MethodGet, URL: "https://www.tibia.com/test?world=Antica"}), time.Now())
+	assert.Nil(err)
+	assert.True(cached)
+
+	crawler.run(context.Background(), crawler.jobs[0])
+	assert.Equal([]string{"Antica", "Zuna", "Antica", "Zuna"}, requested)
+
+	// the worlds that cannot be loaded are an error of the run
+	crawler.worlds = func() ([]string, error) { return nil, errors.New("worlds unavailable") }
+	crawler.run(context.Background(), crawler.jobs[0])
+
+	stats = crawler.Stats()
+	assert.Equal(int64(3), stats.Jobs[0].Runs)
+	assert.Equal(0, stats.Jobs[0].LastRequests)
+	assert.Equal([]string{"worlds unavailable"}, stats.Jobs[0].LastErrors)
+
+	// paths the crawler cannot refresh are an error of the request
+	assert.EqualError(tibiaDataCrawlerRefresh("/v4/unknown"), "/v4/unknown: not an endpoint the crawler can refresh")
+}
+
+func TestCrawlerStart(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	assert := assert.New(t)
+
+	requests := make(chan struct{}, 10)
+	endpoints := tibiaDataCrawlerEndpoints
+	tibiaDataCrawlerEndpoints = []tibiaDataCrawlerEndpoint{{Path: "/v4/worlds", Request: func(params map[string]string) (tibiaDataEndpointRequest, error) {
+		requests <- struct{}{}
+		return tibiaDataEndpointRequest{}, errors.New("not requested")
+	}}}
+	defer func() { tibiaDataCrawlerEndpoints = endpoints }()
+
+	crawler := NewTibiaDataCrawlerScheduler([]TibiaDataCrawlerJob{
+		{Name: "worlds", Path: "/v4/worlds", Interval: 10 * time.Millisecond, Jitter: 5 * time.Millisecond},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	crawler.Start(ctx)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-requests:
+		case <-time.After(time.Second):
+			t.Fatal("the job was not run")
+		}
+	}
+	cancel()
+
+	assert.Eventually(func() bool {
+		return crawler.Stats().Jobs[0].Runs >= 2
+	}, time.Second, 10*time.Millisecond)
+
+	// the admin endpoint returns the state of the crawler
+	previous := TibiaDataCrawler
+	TibiaDataCrawler = crawler
+	defer func() { TibiaDataCrawler = previous }()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	crawlerHandler(c)
+	assert.Equal(http.StatusOK, w.Code)
+
+	var stats TibiaDataCrawlerStats
+	assert.Nil(json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.True(stats.Enabled)
+	assert.Equal("/v4/worlds", stats.Jobs[0].Path)
+	assert.Equal("10ms", stats.Jobs[0].Interval)
+}
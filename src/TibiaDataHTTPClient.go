@@ -0,0 +1,147 @@
+package main
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// TibiaDataHTTPClientConfig stores the settings of the client used for all requests to tibia.com
+type TibiaDataHTTPClientConfig struct {
+	Timeout               time.Duration // The time limit of one attempt of a request.
+	DialTimeout           time.Duration // The time limit to open a connection.
+	TLSHandshakeTimeout   time.Duration // The time limit of the TLS handshake.
+	IdleConnTimeout       time.Duration // How long idle connections are kept open.
+	MaxIdleConns          int           // The idle connections kept open in total.
+	MaxIdleConnsPerHost   int           // The idle connections kept open per host.
+	MaxConnsPerHost       int           // The connections per host (0 is unlimited).
+	HTTP2                 bool          // Whether HTTP/2 is used if the server supports it.
+	TLSMinVersion         uint16        // The minimum TLS version accepted.
+	TLSInsecureSkipVerify bool          // Whether the certificate of the server is not verified (e.g. for a proxy with a self-signed certificate).
+	RetryCount            int           // The retries of requests that failed with a timeout or server error.
+	RetryWaitTime         time.Duration // The wait before the first retry, doubling with every retry.
+	RetryMaxWaitTime      time.Duration // The wait between retries at most.
+}
+
+var (
+	// TibiaDataHTTPClientSettings - the settings of TibiaDataClient
+	// can be overridden by env TIBIADATA_HTTP_*
+	TibiaDataHTTPClientSettings = TibiaDataHTTPClientConfig{
+		Timeout:             5 * time.Second,
+		DialTimeout:         5 * time.Second,
+		TLSHandshakeTimeout: 5 * time.Second,
+		IdleConnTimeout:     90 * time.Second,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 20,
+		HTTP2:               true,
+		TLSMinVersion:       tls.VersionTLS12,
+		RetryCount:          2,
+		RetryWaitTime:       500 * time.Millisecond,
+		RetryMaxWaitTime:    5 * time.Second,
+	}
+
+	// TibiaDataClient - the client shared by all requests to tibia.com, created at startup
+	TibiaDataClient *resty.Client
+)
+
+// NewTibiaDataHTTPClient func - returns a client keeping connections to tibia.com open between requests
+func NewTibiaDataHTTPClient(config TibiaDataHTTPClientConfig, userAgent string) *resty.Client {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   config.DialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout: config.TLSHandshakeTimeout,
+		IdleConnTimeout:     config.IdleConnTimeout,
+		MaxIdleConns:        config.MaxIdleConns,
+		MaxIdleConnsPerHost: config.MaxIdleConnsPerHost,
+		MaxConnsPerHost:     config.MaxConnsPerHost,
+		ForceAttemptHTTP2:   config.HTTP2,
+		TLSClientConfig: &tls.Config{
+			MinVersion:         config.TLSMinVersion,
+			InsecureSkipVerify: config.TLSInsecureSkipVerify, // only if set through env
+		},
+	}
+	if !config.HTTP2 {
+		// an empty map disables HTTP/2
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	// Setting up resty client
+	client := resty.New().SetTransport(transport)
+
+	// Set Debug if enabled by TibiaDataDebug var
+	if TibiaDataDebug {
+		client.SetDebug(true)
+		client.EnableTrace()
+	}
+
+	// Set client timeout and retry with exponential backoff and jitter
+	// throttled requests (403) are not retried, since retrying makes the throttling worse
+	client.SetTimeout(config.Timeout)
+	client.SetRetryCount(config.RetryCount)
+	client.SetRetryWaitTime(config.RetryWaitTime)
+	client.SetRetryMaxWaitTime(config.RetryMaxWaitTime)
+	client.AddRetryCondition(func(res *resty.Response, err error) bool {
+		if err == nil && res.StatusCode() < http.StatusInternalServerError {
+			return false
+		}
+
+		// the last attempt is not retried anyway
+		if res == nil || res.Request == nil || res.Request.Attempt > config.RetryCount {
+			return false
+		}
+
+		// every retry takes its own token of the rate limiter, so retries do not exceed the rate of requests to tibia.com
+		// a retry rejected by the rate limiter is not sent and the outcome of the failed attempt is returned
+		return TibiaDataLimiter.Wait(tibiaDataPriorityFromContext(res.Request.Context())) == nil
+	})
+
+	// Set headers for all requests
+	client.SetHeaders(map[string]string{
+		"Content-Type": "application/json",
+		"User-Agent":   userAgent,
+	})
+
+	// Enabling Content length value for all request
+	client.SetContentLength(true)
+
+	// Disable redirection of client (so we skip parsing maintenance page)
+	// the redirect itself is returned without an error, so TibiaDataHTMLDataCollector can detect the maintenance mode
+	// (resty.NoRedirectPolicy returns an error instead, which hid the location of the redirect)
+	client.SetRedirectPolicy(resty.RedirectPolicyFunc(func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}))
+
+	return client
+}
+
+// TibiaDataHTTPClientConfigFromEnv func - returns the config with the values set through env TIBIADATA_HTTP_*
+func TibiaDataHTTPClientConfigFromEnv(config TibiaDataHTTPClientConfig) TibiaDataHTTPClientConfig {
+	config.Timeout = getEnvAsDuration("TIBIADATA_HTTP_TIMEOUT", config.Timeout)
+	config.DialTimeout = getEnvAsDuration("TIBIADATA_HTTP_DIAL_TIMEOUT", config.DialTimeout)
+	config.TLSHandshakeTimeout = getEnvAsDuration("TIBIADATA_HTTP_TLS_HANDSHAKE_TIMEOUT", config.TLSHandshakeTimeout)
+	config.IdleConnTimeout = getEnvAsDuration("TIBIADATA_HTTP_IDLE_CONN_TIMEOUT", config.IdleConnTimeout)
+	config.MaxIdleConns = getEnvAsInt("TIBIADATA_HTTP_MAX_IDLE_CONNS", config.MaxIdleConns)
+	config.MaxIdleConnsPerHost = getEnvAsInt("TIBIADATA_HTTP_MAX_IDLE_CONNS_PER_HOST", config.MaxIdleConnsPerHost)
+	config.MaxConnsPerHost = getEnvAsInt("TIBIADATA_HTTP_MAX_CONNS_PER_HOST", config.MaxConnsPerHost)
+	config.HTTP2 = getEnvAsBool("TIBIADATA_HTTP_HTTP2", config.HTTP2)
+	config.TLSInsecureSkipVerify = getEnvAsBool("TIBIADATA_HTTP_TLS_INSECURE_SKIP_VERIFY", config.TLSInsecureSkipVerify)
+	config.RetryCount = getEnvAsInt("TIBIADATA_HTTP_RETRY_COUNT", config.RetryCount)
+	config.RetryWaitTime = getEnvAsDuration("TIBIADATA_HTTP_RETRY_WAIT_TIME", config.RetryWaitTime)
+	config.RetryMaxWaitTime = getEnvAsDuration("TIBIADATA_HTTP_RETRY_MAX_WAIT_TIME", config.RetryMaxWaitTime)
+
+	switch strings.TrimSpace(getEnv("TIBIADATA_HTTP_TLS_MIN_VERSION", "")) {
+	case "1.2":
+		config.TLSMinVersion = tls.VersionTLS12
+	case "1.3":
+		config.TLSMinVersion = tls.VersionTLS13
+	}
+
+	return config
+}
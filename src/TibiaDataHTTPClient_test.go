@@ -0,0 +1,171 @@
+package main
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+func TestHTTPClientReusesConnections(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		connections atomic.Int32
+		userAgent   atomic.Value
+	)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent.Store(r.Header.Get("User-Agent"))
+		_, _ = w.Write([]byte("ok"))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := NewTibiaDataHTTPClient(TibiaDataHTTPClientSettings, "TibiaData-API/v4/testing")
+	for i := 0; i < 5; i++ {
+		res, err := client.R().Get(server.URL)
+		assert.Nil(err)
+		assert.Equal("ok", res.String())
+	}
+
+	assert.Equal(int32(1), connections.Load())
+	assert.Equal("TibiaData-API/v4/testing", userAgent.Load())
+}
+
+func TestHTTPClientHTTP2(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	// the certificate of the test server is self-signed
+	config := TibiaDataHTTPClientSettings
+	_, err := NewTibiaDataHTTPClient(config, "").R().Get(server.URL)
+	assert.NotNil(err)
+
+	config.TLSInsecureSkipVerify = true
+	res, err := NewTibiaDataHTTPClient(config, "").R().Get(server.URL)
+	assert.Nil(err)
+	assert.Equal("HTTP/2.0", res.String())
+
+	config.HTTP2 = false
+	res, err = NewTibiaDataHTTPClient(config, "").R().Get(server.URL)
+	assert.Nil(err)
+	assert.Equal("HTTP/1.1", res.String())
+}
+
+func TestHTTPClientRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	config := TibiaDataHTTPClientSettings
+	config.RetryCount = 3
+	config.RetryWaitTime = time.Millisecond
+	config.RetryMaxWaitTime = time.Millisecond
+	client := NewTibiaDataHTTPClient(config, "")
+
+	// server errors are retried
+	res, err := client.R().Get(server.URL)
+	assert.Nil(err)
+	assert.Equal(http.StatusInternalServerError, res.StatusCode())
+	assert.Equal(int32(4), requests.Load())
+
+	// every retry takes a token of the rate limiter, a rejected retry is not sent
+	limiter := TibiaDataLimiter
+	TibiaDataLimiter = NewTibiaDataRateLimiter(0.001, 1, 0)
+	requests.Store(0)
+	res, err = client.R().Get(server.URL)
+	TibiaDataLimiter = limiter
+	assert.Nil(err)
+	assert.Equal(http.StatusInternalServerError, res.StatusCode())
+	assert.Equal(int32(2), requests.Load())
+
+	// every attempt is limited by the timeout
+	requests.Store(0)
+	config.RetryCount = 0
+	config.Timeout = 50 * time.Millisecond
+	_, err = NewTibiaDataHTTPClient(config, "").R().Get(server.URL + "/slow")
+	assert.NotNil(err)
+	assert.Equal(int32(1), requests.Load())
+}
+
+func TestHTTPClientMaintenanceRedirect(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("subtopic") == "maintenance" {
+			http.Redirect(w, r, "https://maintenance.tibia.com/", http.StatusFound)
+			return
+		}
+		http.Redirect(w, r, "/elsewhere", http.StatusFound)
+	}))
+	defer server.Close()
+
+	// the redirect is not followed, but returned without an error
+	res, err := NewTibiaDataHTTPClient(TibiaDataHTTPClientSettings, "").R().Get(server.URL + "/?subtopic=maintenance")
+	assert.Nil(err)
+	assert.Equal(http.StatusFound, res.StatusCode())
+	assert.Equal("https://maintenance.tibia.com/", res.Header().Get("Location"))
+
+	proxies := TibiaDataProxies
+	TibiaDataProxies = NewTibiaDataProxyPool([]string{server.URL + "/"}, TibiaDataProxyRoundRobin, 100, time.Minute, time.Minute)
+	defer func() { TibiaDataProxies = proxies }()
+
+	breaker := TibiaDataBreaker
+	TibiaDataBreaker = NewTibiaDataCircuitBreaker(1, time.Minute, time.Minute)
+	defer func() { TibiaDataBreaker = breaker }()
+
+	// so the collector can tell the maintenance mode apart from other redirects
+	_, err = TibiaDataHTMLDataCollector(TibiaDataRequestStruct{URL: "https://www.tibia.com/?subtopic=maintenance"})
+	assert.Equal(validation.ErrorMaintenanceMode, err)
+
+	_, err = TibiaDataHTMLDataCollector(TibiaDataRequestStruct{URL: "https://www.tibia.com/?subtopic=news"})
+	assert.Equal(validation.ErrStatusFound, err)
+
+	// redirects are answers of tibia.com, so they do not open the circuit
+	assert.Equal(TibiaDataCircuitClosed, TibiaDataBreaker.Stats().State)
+}
+
+func TestHTTPClientConfigFromEnv(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv("TIBIADATA_HTTP_TIMEOUT", "10s")
+	t.Setenv("TIBIADATA_HTTP_MAX_IDLE_CONNS_PER_HOST", "50")
+	t.Setenv("TIBIADATA_HTTP_HTTP2", "false")
+	t.Setenv("TIBIADATA_HTTP_TLS_MIN_VERSION", "1.3")
+	t.Setenv("TIBIADATA_HTTP_RETRY_COUNT", "0")
+	t.Setenv("TIBIADATA_HTTP_RETRY_WAIT_TIME", "invalid")
+
+	config := TibiaDataHTTPClientConfigFromEnv(TibiaDataHTTPClientSettings)
+	assert.Equal(10*time.Second, config.Timeout)
+	assert.Equal(50, config.MaxIdleConnsPerHost)
+	assert.False(config.HTTP2)
+	assert.Equal(uint16(tls.VersionTLS13), config.TLSMinVersion)
+	assert.Equal(0, config.RetryCount)
+	assert.Equal(TibiaDataHTTPClientSettings.RetryWaitTime, config.RetryWaitTime)
+	assert.Equal(TibiaDataHTTPClientSettings.DialTimeout, config.DialTimeout)
+}
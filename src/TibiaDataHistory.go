@@ -0,0 +1,462 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+const (
+	// TibiaDataHistoryDefaultLimit - the number of snapshots returned by the history endpoints by default
+	TibiaDataHistoryDefaultLimit = 100
+
+	// TibiaDataHistoryMaxLimit - the number of snapshots returned by the history endpoints at most
+	TibiaDataHistoryMaxLimit = 1000
+)
+
+// TibiaDataSnapshot stores the data of a response at one point in time
+type TibiaDataSnapshot struct {
+	Timestamp string          `json:"timestamp"` // The time the data was fetched from tibia.com.
+	Data      json.RawMessage `json:"data"`      // The data as returned by the endpoint.
+}
+
+// tibiaDataHistoryKind describes the snapshots recorded of the responses of a handler
+type tibiaDataHistoryKind struct {
+	Kind      string                                            // The kind of data, used in the path of the history endpoint.
+	Snapshots func(jsonData interface{}) map[string]interface{} // Returns the data of the snapshots by their key (empty keys are not recorded).
+}
+
+// tibiaDataHistoryFormerName is the character a former name belonged to
+type tibiaDataHistoryFormerName struct {
+	Name string `json:"name"` // The name of the character at the time of the snapshot.
+}
+
+// tibiaDataHistoryRecord is a snapshot waiting to be written
+type tibiaDataHistoryRecord struct {
+	kind     string
+	key      string
+	snapshot TibiaDataSnapshot
+}
+
+// TibiaDataHistoryStore records snapshots of responses in JSON lines files, one file per kind and key
+// snapshots are written in the background, so requests do not wait for the disk
+type TibiaDataHistoryStore struct {
+	dir       string
+	interval  time.Duration
+	retention time.Duration
+
+	mu      sync.Mutex
+	files   map[string]*sync.RWMutex // guards every file by its path, so a slow file does not block the others
+	lastMu  sync.Mutex
+	last    map[string]time.Time // kind and key of the last recorded snapshots
+	records chan tibiaDataHistoryRecord
+}
+
+var (
+	// TibiaDataHistory - the store of snapshots (nil if the history is disabled)
+	TibiaDataHistory *TibiaDataHistoryStore
+
+	// TibiaDataHistoryKinds - the snapshots recorded per handler name
+	TibiaDataHistoryKinds = map[string][]tibiaDataHistoryKind{
+		"TibiaCharactersCharacter": {
+			{Kind: "character", Snapshots: func(jsonData interface{}) map[string]interface{} {
+				response, _ := jsonData.(CharacterResponse)
+				return map[string]interface{}{response.Character.CharacterInfo.Name: response.Character}
+			}},
+			// the character every former name belonged to, used to find a character whose former name was taken
+			{Kind: "formername", Snapshots: func(jsonData interface{}) map[string]interface{} {
+				response, _ := jsonData.(CharacterResponse)
+				snapshots := make(map[string]interface{}, len(response.Character.CharacterInfo.FormerNames))
+				for _, formerName := range response.Character.CharacterInfo.FormerNames {
+					snapshots[formerName] = tibiaDataHistoryFormerName{Name: response.Character.CharacterInfo.Name}
+				}
+				return snapshots
+			}},
+		},
+		"TibiaGuildsGuild": {{Kind: "guild", Snapshots: func(jsonData interface{}) map[string]interface{} {
+			response, _ := jsonData.(GuildResponse)
+			return map[string]interface{}{response.Guild.Name: response.Guild}
+		}}},
+		"TibiaWorldsWorld": {{Kind: "world", Snapshots: func(jsonData interface{}) map[string]interface{} {
+			response, _ := jsonData.(WorldResponse)
+			return map[string]interface{}{response.World.Name: response.World}
+		}}},
+		"TibiaHighscores": {
+			{Kind: "highscores", Snapshots: func(jsonData interface{}) map[string]interface{} {
+				response, _ := jsonData.(HighscoresResponse)
+				if response.Highscores.Category == "" {
+					return nil
+				}
+				return map[string]interface{}{
+					tibiaDataHistoryHighscoresKey(response.Highscores.World, response.Highscores.Category, response.Highscores.Vocation, response.Highscores.HighscorePage.CurrentPage): response.Highscores,
+				}
+			}},
+			// the experience of every character on an experience page, used by the progress of characters
+			{Kind: "experience", Snapshots: func(jsonData interface{}) map[string]interface{} {
+				response, _ := jsonData.(HighscoresResponse)
+				if response.Highscores.Category != "experience" {
+					return nil
+				}
+				snapshots := make(map[string]interface{}, len(response.Highscores.HighscoreList))
+				for _, highscore := range response.Highscores.HighscoreList {
+					snapshots[highscore.Name] = highscore
+				}
+				return snapshots
+			}},
+		},
+		"TibiaKillstatistics": {{Kind: "killstatistics", Snapshots: func(jsonData interface{}) map[string]interface{} {
+			response, _ := jsonData.(KillStatisticsResponse)
+			return map[string]interface{}{response.KillStatistics.World: response.KillStatistics}
+		}}},
+	}
+)
+
+// NewTibiaDataHistoryStore func - returns a store in dir recording a snapshot per kind and key at most every interval
+// snapshots older than retention are purged (0 keeps them forever)
+func NewTibiaDataHistoryStore(dir string, interval, retention time.Duration) (*TibiaDataHistoryStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	store := &TibiaDataHistoryStore{
+		dir:       dir,
+		interval:  interval,
+		retention: retention,
+		files:     make(map[string]*sync.RWMutex),
+		last:      make(map[string]time.Time),
+		records:   make(chan tibiaDataHistoryRecord, 1000),
+	}
+	go store.run()
+
+	return store, nil
+}
+
+// Record func - records the snapshots of the response of the handler in the background
+// responses of handlers without history and snapshots within the interval of the last one are skipped
+func (s *TibiaDataHistoryStore) Record(handlerName string, jsonData interface{}, created time.Time) {
+	if s == nil {
+		return
+	}
+
+	for _, kind := range TibiaDataHistoryKinds[handlerName] {
+		for key, data := range kind.Snapshots(jsonData) {
+			if key != "" {
+				s.record(kind.Kind, key, data, created)
+			}
+		}
+	}
+}
+
+// Save func - writes the snapshot of the kind and key right away
+func (s *TibiaDataHistoryStore) Save(kind, key string, snapshot TibiaDataSnapshot) error {
+	line, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	path := s.path(kind, key)
+	lock := s.lock(path)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// Query func - returns the last limit snapshots of the kind and key taken between from and to, oldest first
+// snapshots are appended in the order they were taken, so the file is only read up to the first one after to
+func (s *TibiaDataHistoryStore) Query(kind, key string, from, to time.Time, limit int) ([]TibiaDataSnapshot, error) {
+	// only the last limit snapshots are kept while reading, the oldest one is overwritten first
+	snapshots := []TibiaDataSnapshot{}
+	oldest := 0
+	err := s.Each(kind, key, to, func(snapshot TibiaDataSnapshot, created time.Time) bool {
+		if created.Before(from) {
+			return true
+		}
+
+		if limit > 0 && len(snapshots) == limit {
+			snapshots[oldest] = snapshot
+			oldest = (oldest + 1) % limit
+		} else {
+			snapshots = append(snapshots, snapshot)
+		}
+		return true
+	})
+
+	return append(snapshots[oldest:], snapshots[:oldest]...), err
+}
+
+// Each func - calls fn with the snapshots of the kind and key taken up to to, oldest first, until fn returns false
+// the file is read line by line, so the snapshots are never loaded at once
+func (s *TibiaDataHistoryStore) Each(kind, key string, to time.Time, fn func(snapshot TibiaDataSnapshot, created time.Time) bool) error {
+	path := s.path(kind, key)
+	lock := s.lock(path)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	return s.read(path, func(snapshot TibiaDataSnapshot, created time.Time) bool {
+		return !created.After(to) && fn(snapshot, created)
+	})
+}
+
+// Exists func - reports whether a snapshot of the kind and key was ever recorded
+func (s *TibiaDataHistoryStore) Exists(kind, key string) bool {
+	if s == nil {
+		return false
+	}
+
+	path := s.path(kind, key)
+	lock := s.lock(path)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Purge func - removes the snapshots older than the retention
+// every file is locked on its own while it is rewritten, files without expired snapshots are left as they are
+func (s *TibiaDataHistoryStore) Purge(now time.Time) error {
+	if s.retention <= 0 {
+		return nil
+	}
+
+	cutoff := now.Add(-s.retention)
+	return filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".jsonl" {
+			return err
+		}
+
+		return s.purge(path, cutoff)
+	})
+}
+
+// purge func - removes the snapshots of the file taken before cutoff
+func (s *TibiaDataHistoryStore) purge(path string, cutoff time.Time) error {
+	lock := s.lock(path)
+
+	// snapshots are appended in the order they were taken, so only the first one has to be checked
+	var expired bool
+	lock.RLock()
+	err := s.read(path, func(snapshot TibiaDataSnapshot, created time.Time) bool {
+		expired = created.Before(cutoff)
+		return false
+	})
+	lock.RUnlock()
+	if err != nil || !expired {
+		return err
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	var kept bytes.Buffer
+	err = s.read(path, func(snapshot TibiaDataSnapshot, created time.Time) bool {
+		if created.Before(cutoff) {
+			return true
+		}
+
+		line, _ := json.Marshal(snapshot)
+		kept.Write(append(line, '\n'))
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	if kept.Len() == 0 {
+		return os.Remove(path)
+	}
+
+	// replacing the file at once, so it is never read half written
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, kept.Bytes(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// record func - queues the snapshot of the kind and key unless one was recorded within the interval
+func (s *TibiaDataHistoryStore) record(kind, key string, data interface{}, created time.Time) {
+	lastKey := kind + "/" + strings.ToLower(key)
+	s.lastMu.Lock()
+	if last, ok := s.last[lastKey]; ok && created.Sub(last) < s.interval {
+		s.lastMu.Unlock()
+		return
+	}
+	s.last[lastKey] = created
+	s.lastMu.Unlock()
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("[warning] TibiaDataHistory: %s snapshot of %s could not be encoded: %s", kind, key, err)
+		return
+	}
+
+	record := tibiaDataHistoryRecord{
+		kind: kind,
+		key:  key,
+		snapshot: TibiaDataSnapshot{
+			Timestamp: created.UTC().Format(time.RFC3339),
+			Data:      raw,
+		},
+	}
+
+	select {
+	case s.records <- record:
+	default:
+		// the disk cannot keep up, so the next response is recorded instead
+		s.lastMu.Lock()
+		delete(s.last, lastKey)
+		s.lastMu.Unlock()
+		log.Printf("[warning] TibiaDataHistory: %s snapshot of %s dropped, too many snapshots are queued", kind, key)
+	}
+}
+
+// forget func - removes the last recorded snapshots older than the interval, they no longer skip any snapshot
+func (s *TibiaDataHistoryStore) forget(now time.Time) {
+	s.lastMu.Lock()
+	defer s.lastMu.Unlock()
+
+	for lastKey, last := range s.last {
+		if now.Sub(last) >= s.interval {
+			delete(s.last, lastKey)
+		}
+	}
+}
+
+// run func - writes the recorded snapshots, purges old ones and forgets expired last recorded snapshots every hour
+func (s *TibiaDataHistoryStore) run() {
+	purge := time.NewTicker(time.Hour)
+	defer purge.Stop()
+
+	for {
+		select {
+		case record := <-s.records:
+			if err := s.Save(record.kind, record.key, record.snapshot); err != nil {
+				log.Printf("[warning] TibiaDataHistory: %s snapshot of %s could not be saved: %s", record.kind, record.key, err)
+			}
+		case now := <-purge.C:
+			s.forget(now)
+			if err := s.Purge(now); err != nil {
+				log.Printf("[warning] TibiaDataHistory: purge failed: %s", err)
+			}
+		}
+	}
+}
+
+// read func - calls fn with every snapshot of the file until it returns false (lines that cannot be parsed are skipped)
+func (s *TibiaDataHistoryStore) read(path string, fn func(snapshot TibiaDataSnapshot, created time.Time) bool) error {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var snapshot TibiaDataSnapshot
+			if json.Unmarshal(line, &snapshot) == nil {
+				if created, err := time.Parse(time.RFC3339, snapshot.Timestamp); err == nil && !fn(snapshot, created) {
+					return nil
+				}
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// lock func - returns the lock of the file
+// locks are kept after a file was removed, so everyone waiting for it still shares the same lock
+func (s *TibiaDataHistoryStore) lock(path string) *sync.RWMutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lock, ok := s.files[path]
+	if !ok {
+		lock = &sync.RWMutex{}
+		s.files[path] = lock
+	}
+
+	return lock
+}
+
+// path func - returns the file of the kind and key, keys are case insensitive
+func (s *TibiaDataHistoryStore) path(kind, key string) string {
+	return filepath.Join(s.dir, kind, url.PathEscape(strings.ToLower(key))+".jsonl")
+}
+
+// tibiaDataHistoryFormerOwner func - returns the recorded character the name belonged to before it was renamed
+// returns an empty string if there is none or the name was not recorded as former name
+func tibiaDataHistoryFormerOwner(name string) (string, error) {
+	snapshots, err := TibiaDataHistory.Query("formername", name, time.Time{}, time.Now(), 1)
+	if err != nil || len(snapshots) == 0 {
+		return "", err
+	}
+
+	var formerName tibiaDataHistoryFormerName
+	if err := json.Unmarshal(snapshots[0].Data, &formerName); err != nil {
+		return "", err
+	}
+
+	return formerName.Name, nil
+}
+
+// tibiaDataHistoryCharacterNotFound func - returns the error of a character tibia.com could not find
+// tibia.com shows deleted and banished characters like names that never existed, so only the history can tell them apart
+func tibiaDataHistoryCharacterNotFound(name string) error {
+	switch {
+	case TibiaDataHistory == nil:
+		return validation.ErrorCharacterStateUnknown
+	case TibiaDataHistory.Exists("character", name):
+		return validation.ErrorCharacterDeleted
+	default:
+		return validation.ErrorCharacterNotFound
+	}
+}
+
+// tibiaDataHistoryHighscoresKey func - returns the key of a highscores page (an empty world is all worlds)
+func tibiaDataHistoryHighscoresKey(world, category, vocation string, page int) string {
+	if world == "" {
+		world = "all"
+	}
+
+	return strings.Join([]string{world, category, vocation, strconv.Itoa(page)}, "/")
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/static"
+)
+
+func TestHistoryStore(t *testing.T) {
+	assert := assert.New(t)
+
+	store, err := NewTibiaDataHistoryStore(t.TempDir(), time.Hour, 48*time.Hour)
+	assert.Nil(err)
+
+	now := time.Now().Truncate(time.Second)
+	for i := 3; i >= 0; i-- {
+		assert.Nil(store.Save("guild", "Elysium", TibiaDataSnapshot{
+			Timestamp: now.Add(-time.Duration(i) * 24 * time.Hour).UTC().Format(time.RFC3339),
+			Data:      json.RawMessage(`{"day":` + strconv.Itoa(i) + `}`),
+		}))
+	}
+
+	// names are case insensitive and the snapshots are returned oldest first
+	snapshots, err := store.Query("guild", "ELYSIUM", time.Time{}, now, 0)
+	assert.Nil(err)
+	assert.Len(snapshots, 4)
+	assert.Equal(`{"day":3}`, string(snapshots[0].Data))
+
+	// the range and the limit are applied
+	snapshots, err = store.Query("guild", "elysium", now.Add(-50*time.Hour), now.Add(-time.Hour), 0)
+	assert.Nil(err)
+	assert.Len(snapshots, 2)
+	assert.Equal(`{"day":2}`, string(snapshots[0].Data))
+
+	snapshots, err = store.Query("guild", "elysium", time.Time{}, now, 1)
+	assert.Nil(err)
+	assert.Len(snapshots, 1)
+	assert.Equal(`{"day":0}`, string(snapshots[0].Data))
+
+	snapshots, err = store.Query("guild", "elysium", time.Time{}, now.Add(-time.Hour), 2)
+	assert.Nil(err)
+	assert.Len(snapshots, 2)
+	assert.Equal(`{"day":2}`, string(snapshots[0].Data))
+	assert.Equal(`{"day":1}`, string(snapshots[1].Data))
+
+	// the snapshots are streamed up to the time until the callback stops
+	var streamed []string
+	assert.Nil(store.Each("guild", "elysium", now.Add(-time.Hour), func(snapshot TibiaDataSnapshot, created time.Time) bool {
+		streamed = append(streamed, string(snapshot.Data))
+		return len(streamed) < 2
+	}))
+	assert.Equal([]string{`{"day":3}`, `{"day":2}`}, streamed)
+
+	// unknown names have no snapshots
+	snapshots, err = store.Query("guild", "Unknown", time.Time{}, now, 0)
+	assert.Nil(err)
+	assert.Empty(snapshots)
+
+	// snapshots older than the retention are purged
+	assert.Nil(store.Purge(now))
+	snapshots, err = store.Query("guild", "elysium", time.Time{}, now, 0)
+	assert.Nil(err)
+	assert.Len(snapshots, 3)
+
+	// files without expired snapshots are not rewritten
+	purged, err := os.Stat(store.path("guild", "elysium"))
+	assert.Nil(err)
+	assert.Nil(store.Purge(now))
+	unchanged, err := os.Stat(store.path("guild", "elysium"))
+	assert.Nil(err)
+	assert.True(os.SameFile(purged, unchanged))
+
+	assert.Nil(store.Purge(now.Add(72 * time.Hour)))
+	_, err = os.Stat(store.path("guild", "elysium"))
+	assert.True(os.IsNotExist(err))
+}
+
+func TestHistoryRecord(t *testing.T) {
+	assert := assert.New(t)
+
+	store, err := NewTibiaDataHistoryStore(t.TempDir(), time.Hour, 0)
+	assert.Nil(err)
+
+	now := time.Now()
+	guild := GuildResponse{Guild: Guild{Name: "Elysium", World: "Antica"}}
+
+	// only one snapshot is recorded per interval and responses without history are skipped
+	store.Record("TibiaGuildsGuild", guild, now)
+	store.Record("TibiaGuildsGuild", guild, now.Add(time.Minute))
+	store.Record("TibiaFansites", FansitesResponse{}, now)
+	store.Record("TibiaGuildsGuild", guild, now.Add(time.Hour))
+
+	assert.Eventually(func() bool {
+		snapshots, _ := store.Query("guild", "Elysium", time.Time{}, now.Add(time.Hour), 0)
+		return len(snapshots) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	snapshots, _ := store.Query("guild", "Elysium", time.Time{}, now.Add(time.Hour), 0)
+	var recorded Guild
+	assert.Nil(json.Unmarshal(snapshots[0].Data, &recorded))
+	assert.Equal("Antica", recorded.World)
+
+	// the key of highscores contains the page
+	store.Record("TibiaHighscores", HighscoresResponse{Highscores: Highscores{Category: "experience", Vocation: "all", HighscorePage: HighscorePage{CurrentPage: 2}}}, now)
+	assert.Eventually(func() bool {
+		snapshots, _ := store.Query("highscores", "all/experience/all/2", time.Time{}, now, 0)
+		return len(snapshots) == 1
+	}, time.Second, 10*time.Millisecond)
+	_, err = os.Stat(filepath.Join(store.dir, "highscores", "all%2Fexperience%2Fall%2F2.jsonl"))
+	assert.Nil(err)
+
+	// the last recorded snapshots are forgotten once the interval has passed
+	store.forget(now.Add(90 * time.Minute))
+	store.lastMu.Lock()
+	assert.Len(store.last, 1)
+	assert.Contains(store.last, "guild/elysium")
+	store.lastMu.Unlock()
+
+	store.forget(now.Add(3 * time.Hour))
+	store.lastMu.Lock()
+	assert.Empty(store.last)
+	store.lastMu.Unlock()
+}
+
+func TestHistoryRequestHandler(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/killstatistics/Antica.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<div class="Border_2"><div class="Border_3">` + string(data) + `</div></div>`))
+	}))
+	defer server.Close()
+
+	proxies := TibiaDataProxies
+	TibiaDataProxies = NewTibiaDataProxyPool([]string{server.URL + "/"}, TibiaDataProxyRoundRobin, 100, time.Minute, time.Minute)
+	defer func() { TibiaDataProxies = proxies }()
+
+	cache := TibiaDataCache
+	TibiaDataCache = NewTibiaDataCacheMemory(10)
+	defer func() { TibiaDataCache = cache }()
+
+	history := TibiaDataHistory
+	TibiaDataHistory, err = NewTibiaDataHistoryStore(t.TempDir(), time.Hour, 0)
+	assert.Nil(err)
+	defer func() { TibiaDataHistory = history }()
+
+	// fetched responses are recorded
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method: resty.MethodGet,
+		URL:    "https://www.tibia.com/community/?subtopic=killstatistics&world=Antica",
+	}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	tibiaDataRequestHandler(c, tibiadataRequest, func(BoxContentHTML string) (interface{}, error) {
+		return TibiaKillstatisticsImpl("Antica", BoxContentHTML, tibiadataRequest.URL)
+	}, "TibiaKillstatistics")
+	assert.Equal(http.StatusOK, w.Code)
+
+	assert.Eventually(func() bool {
+		snapshots, _ := TibiaDataHistory.Query("killstatistics", "Antica", time.Time{}, time.Now(), 0)
+		return len(snapshots) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v4/history/killstatistics/antica?from=2020-01-01", nil)
+	tibiaDataHistoryHandler(c, "killstatistics", "Antica")
+	assert.Equal(http.StatusOK, w.Code)
+
+	var response HistoryResponse
+	assert.Nil(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal("killstatistics", response.History.Type)
+	assert.Equal("2020-01-01T00:00:00Z", response.History.From)
+	assert.Len(response.History.Snapshots, 1)
+	assert.Contains(string(response.History.Snapshots[0].Data), `"world":"Antica"`)
+
+	// the range must be valid
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v4/history/killstatistics/antica?from=2020-01-02&to=2020-01-01", nil)
+	tibiaDataHistoryHandler(c, "killstatistics", "Antica")
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Contains(w.Body.String(), `"error":9004`)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v4/history/killstatistics/antica?to=yesterday", nil)
+	tibiaDataHistoryHandler(c, "killstatistics", "Antica")
+	assert.Equal(http.StatusBadRequest, w.Code)
+
+	// the history must be enabled
+	TibiaDataHistory = nil
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v4/history/guild/Elysium", nil)
+	c.Params = []gin.Param{{Key: "name", Value: "Elysium"}}
+	tibiaHistoryGuild(c)
+	assert.Equal(http.StatusNotFound, w.Code)
+	assert.Contains(w.Body.String(), `"error":9003`)
+}
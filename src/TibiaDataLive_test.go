@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// tibiaDataLivePage func - returns the page of tibia.com for the live tests of the parsers
+// the live tests are skipped unless env TIBIADATA_LIVE_TESTS is set, since they depend on tibia.com
+// with env TIBIADATA_CAPTURE_TESTDATA set as well, the page replaces the file of the testdata
+func tibiaDataLivePage(t *testing.T, url, testdata string) string {
+	t.Helper()
+
+	if os.Getenv("TIBIADATA_LIVE_TESTS") == "" {
+		t.Skip("set env TIBIADATA_LIVE_TESTS to request tibia.com")
+	}
+
+	data, err := TibiaDataHTMLDataCollector(TibiaDataRequestStruct{
+		Method:  resty.MethodGet,
+		URL:     url,
+		RawBody: true,
+	})
+	if err != nil {
+		t.Fatalf("requesting %s failed: %s", url, err)
+	}
+
+	if testdata != "" && os.Getenv("TIBIADATA_CAPTURE_TESTDATA") != "" {
+		if err := os.WriteFile(filepath.Join("static", "testdata", testdata), []byte(data), 0o644); err != nil {
+			t.Fatalf("writing %s failed: %s", testdata, err)
+		}
+	}
+
+	return data
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+// strategies to select the proxy of a request
+const (
+	TibiaDataProxyRoundRobin     = "round-robin"     // The proxies are used one after another.
+	TibiaDataProxyLeastThrottled = "least-throttled" // The proxy throttled the least recently is used.
+)
+
+// states of a proxy
+const (
+	TibiaDataProxyHealthy   = "healthy"   // The proxy is used for requests.
+	TibiaDataProxyEjected   = "ejected"   // The proxy is not used until the ejection has passed.
+	TibiaDataProxyProbation = "probation" // One request is sent to find out whether the proxy recovered.
+)
+
+// TibiaDataProxyThrottleHalfLife - how long it takes until half of the throttled requests of a proxy are forgotten
+const TibiaDataProxyThrottleHalfLife = time.Minute
+
+// TibiaDataProxyRecentRequests - the number of requests shown on /debug
+const TibiaDataProxyRecentRequests = 50
+
+// TibiaDataProxyStats stores the state of a proxy
+type TibiaDataProxyStats struct {
+	URL                 string  `json:"url"`                     // The URL of the proxy replacing https://www.tibia.com/.
+	State               string  `json:"state"`                   // The state of the proxy (healthy, ejected or probation).
+	Requests            int64   `json:"requests"`                // The number of requests sent through the proxy.
+	Throttled           int64   `json:"throttled"`               // The number of throttled requests (403) sent through the proxy.
+	Failed              int64   `json:"failed"`                  // The number of requests sent through the proxy that failed with a timeout or server error.
+	ThrottleScore       float64 `json:"throttle_score"`          // The recently throttled requests, used by the least-throttled strategy.
+	ConsecutiveFailures int     `json:"consecutive_failures"`    // The number of failed requests since the last successful one.
+	Ejections           int     `json:"ejections"`               // The number of times the proxy was ejected since it last recovered.
+	EjectedUntil        string  `json:"ejected_until,omitempty"` // The time the proxy is used again.
+	LastError           string  `json:"last_error,omitempty"`    // The error of the last failed request.
+}
+
+// TibiaDataProxyRequest stores which proxy served a request
+type TibiaDataProxyRequest struct {
+	Timestamp string `json:"timestamp"`       // The time the request was completed.
+	Proxy     string `json:"proxy"`           // The URL of the proxy.
+	URL       string `json:"url"`             // The URL requested through the proxy.
+	Status    int    `json:"status"`          // The HTTP status of the response (0 if there was none).
+	Error     string `json:"error,omitempty"` // The error of the request.
+}
+
+// TibiaDataProxyPoolStats stores the state of all proxies
+type TibiaDataProxyPoolStats struct {
+	Strategy string                  `json:"strategy"` // The strategy to select the proxy of a request.
+	Proxies  []TibiaDataProxyStats   `json:"proxies"`  // The state of every proxy.
+	Requests []TibiaDataProxyRequest `json:"requests"` // The most recent requests, newest first.
+}
+
+// TibiaDataProxy is a proxy of the pool, which is returned by Pick and has to be passed to Done
+type TibiaDataProxy struct {
+	URL string
+
+	requests     int64
+	throttled    int64
+	failed       int64
+	score        float64
+	scoredAt     time.Time
+	failures     int
+	ejections    int
+	ejectedUntil time.Time
+	trial        bool // whether the request of the probation is in flight
+	lastError    string
+}
+
+// TibiaDataProxyPool selects the proxy of every request to tibia.com
+// proxies failing repeatedly are ejected for a while, which grows exponentially with every ejection,
+// and have to serve one request successfully before they are used again
+type TibiaDataProxyPool struct {
+	strategy         string
+	threshold        int
+	ejectDuration    time.Duration
+	maxEjectDuration time.Duration
+
+	mu       sync.Mutex
+	proxies  []*TibiaDataProxy
+	next     int
+	recent   []TibiaDataProxyRequest
+	recentAt int
+}
+
+// TibiaDataProxies - the proxies used by TibiaDataHTMLDataCollector (none by default)
+var TibiaDataProxies = NewTibiaDataProxyPool(nil, TibiaDataProxyRoundRobin, 3, 30*time.Second, 10*time.Minute)
+
+// NewTibiaDataProxyPool func - returns a pool of the proxy URLs ejecting a proxy after threshold consecutive failures
+func NewTibiaDataProxyPool(urls []string, strategy string, threshold int, ejectDuration, maxEjectDuration time.Duration) *TibiaDataProxyPool {
+	pool := &TibiaDataProxyPool{
+		strategy:         strategy,
+		threshold:        max(threshold, 1),
+		ejectDuration:    ejectDuration,
+		maxEjectDuration: max(maxEjectDuration, ejectDuration),
+		recent:           make([]TibiaDataProxyRequest, 0, TibiaDataProxyRecentRequests),
+	}
+	for _, url := range urls {
+		pool.proxies = append(pool.proxies, &TibiaDataProxy{URL: url})
+	}
+
+	return pool
+}
+
+// TibiaDataProxyURLs func - returns the proxy URLs of a comma separated list of domains
+// the protocol is used for domains without one
+func TibiaDataProxyURLs(domains, protocol string) []string {
+	var urls []string
+	for _, domain := range strings.Split(domains, ",") {
+		domain = strings.TrimSpace(domain)
+		if domain == "" {
+			continue
+		}
+		if !strings.HasPrefix(domain, "http://") && !strings.HasPrefix(domain, "https://") {
+			domain = protocol + "://" + domain
+		}
+		urls = append(urls, strings.TrimSuffix(domain, "/")+"/")
+	}
+
+	return urls
+}
+
+// Pick func - returns the proxy of the next request or nil if there are no proxies
+// returns TibiaDataUnavailableError if all proxies are ejected
+func (p *TibiaDataProxyPool) Pick(now time.Time) (*TibiaDataProxy, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.proxies) == 0 {
+		return nil, nil
+	}
+
+	var (
+		picked     *TibiaDataProxy
+		pickedAt   int
+		readmitted time.Time
+	)
+	for i := range p.proxies {
+		index := (p.next + i) % len(p.proxies)
+		proxy := p.proxies[index]
+
+		switch p.state(proxy, now) {
+		case TibiaDataProxyEjected:
+			if readmitted.IsZero() || proxy.ejectedUntil.Before(readmitted) {
+				readmitted = proxy.ejectedUntil
+			}
+			continue
+		case TibiaDataProxyProbation:
+			if proxy.trial {
+				continue
+			}
+		}
+
+		if picked == nil || (p.strategy == TibiaDataProxyLeastThrottled && proxy.throttleScore(now) < picked.throttleScore(now)) {
+			picked, pickedAt = proxy, index
+		}
+		if p.strategy != TibiaDataProxyLeastThrottled {
+			break
+		}
+	}
+
+	if picked == nil {
+		retryAfter := p.ejectDuration
+		if !readmitted.IsZero() {
+			retryAfter = readmitted.Sub(now)
+		}
+		return nil, TibiaDataUnavailableError{Err: validation.ErrorUpstreamProxiesEjected, RetryAfter: retryAfter}
+	}
+
+	if p.state(picked, now) == TibiaDataProxyProbation {
+		picked.trial = true
+	}
+	p.next = (pickedAt + 1) % len(p.proxies)
+
+	return picked, nil
+}
+
+// Done func - records the outcome of a request sent through the proxy
+// throttling, server errors and timeouts count as failures of the proxy
+func (p *TibiaDataProxyPool) Done(proxy *TibiaDataProxy, now time.Time, url string, status int, err error) {
+	if proxy == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	proxy.requests++
+	proxy.trial = false
+
+	request := TibiaDataProxyRequest{
+		Timestamp: now.UTC().Format(time.RFC3339),
+		Proxy:     proxy.URL,
+		URL:       url,
+		Status:    status,
+	}
+
+	switch {
+	case err != nil:
+		proxy.failed++
+		request.Error = err.Error()
+		p.failure(proxy, now, request.Error)
+	case status == http.StatusForbidden:
+		proxy.throttled++
+		proxy.score = proxy.throttleScore(now) + 1
+		proxy.scoredAt = now
+		p.failure(proxy, now, fmt.Sprintf("tibia.com responded with status %d", status))
+	case status >= http.StatusInternalServerError:
+		proxy.failed++
+		p.failure(proxy, now, fmt.Sprintf("tibia.com responded with status %d", status))
+	default:
+		proxy.failures = 0
+		proxy.ejections = 0
+	}
+
+	// keeping the most recent requests in a ring
+	if len(p.recent) < TibiaDataProxyRecentRequests {
+		p.recent = append(p.recent, request)
+	} else {
+		p.recent[p.recentAt] = request
+	}
+	p.recentAt = (p.recentAt + 1) % TibiaDataProxyRecentRequests
+}
+
+// Alternative func - reports whether a proxy other than the failed one is healthy,
+// so the failure is tied to the proxy rather than to tibia.com
+func (p *TibiaDataProxyPool) Alternative(failed *TibiaDataProxy, now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, proxy := range p.proxies {
+		if proxy != failed && p.state(proxy, now) == TibiaDataProxyHealthy {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Probe func - sends the request of the probation through every proxy whose ejection has passed,
+// so no request of a user has to find out whether the proxy recovered
+func (p *TibiaDataProxyPool) Probe(now time.Time, probe func(proxy *TibiaDataProxy) (int, error)) {
+	p.mu.Lock()
+	var probed []*TibiaDataProxy
+	for _, proxy := range p.proxies {
+		if p.state(proxy, now) == TibiaDataProxyProbation && !proxy.trial {
+			proxy.trial = true
+			probed = append(probed, proxy)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, proxy := range probed {
+		status, err := probe(proxy)
+		p.Done(proxy, time.Now(), proxy.URL, status, err)
+	}
+}
+
+// RunProbes func - probes the ejected proxies every interval until the context is done
+func (p *TibiaDataProxyPool) RunProbes(ctx context.Context, interval time.Duration, probe func(proxy *TibiaDataProxy) (int, error)) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			p.Probe(now, probe)
+		}
+	}
+}
+
+// Stats func - returns the state of all proxies
+func (p *TibiaDataProxyPool) Stats() TibiaDataProxyPoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	stats := TibiaDataProxyPoolStats{
+		Strategy: p.strategy,
+		Proxies:  make([]TibiaDataProxyStats, 0, len(p.proxies)),
+		Requests: make([]TibiaDataProxyRequest, 0, len(p.recent)),
+	}
+	for _, proxy := range p.proxies {
+		proxyStats := TibiaDataProxyStats{
+			URL:                 proxy.URL,
+			State:               p.state(proxy, now),
+			Requests:            proxy.requests,
+			Throttled:           proxy.throttled,
+			Failed:              proxy.failed,
+			ThrottleScore:       math.Round(proxy.throttleScore(now)*100) / 100,
+			ConsecutiveFailures: proxy.failures,
+			Ejections:           proxy.ejections,
+			LastError:           proxy.lastError,
+		}
+		if proxyStats.State == TibiaDataProxyEjected {
+			proxyStats.EjectedUntil = proxy.ejectedUntil.UTC().Format(time.RFC3339)
+		}
+		stats.Proxies = append(stats.Proxies, proxyStats)
+	}
+
+	// newest first
+	for i := 1; i <= len(p.recent); i++ {
+		stats.Requests = append(stats.Requests, p.recent[(p.recentAt-i+len(p.recent))%len(p.recent)])
+	}
+
+	return stats
+}
+
+// state func - returns the state of the proxy (the pool must be locked)
+func (p *TibiaDataProxyPool) state(proxy *TibiaDataProxy, now time.Time) string {
+	switch {
+	case proxy.failures < p.threshold:
+		return TibiaDataProxyHealthy
+	case now.Before(proxy.ejectedUntil):
+		return TibiaDataProxyEjected
+	default:
+		return TibiaDataProxyProbation
+	}
+}
+
+// failure func - counts the failure and ejects the proxy once the threshold is reached (the pool must be locked)
+func (p *TibiaDataProxyPool) failure(proxy *TibiaDataProxy, now time.Time, lastError string) {
+	proxy.failures++
+	proxy.lastError = lastError
+
+	// requests sent before the proxy was ejected do not extend the ejection
+	if proxy.failures < p.threshold || now.Before(proxy.ejectedUntil) {
+		return
+	}
+
+	// doubling the ejection with every further ejection
+	duration := p.ejectDuration << min(proxy.ejections, 30)
+	if duration <= 0 || duration > p.maxEjectDuration {
+		duration = p.maxEjectDuration
+	}
+
+	proxy.ejections++
+	proxy.ejectedUntil = now.Add(duration)
+}
+
+// throttleScore func - returns the throttled requests of the proxy, halving every TibiaDataProxyThrottleHalfLife
+func (proxy *TibiaDataProxy) throttleScore(now time.Time) float64 {
+	if proxy.score == 0 {
+		return 0
+	}
+
+	return proxy.score * math.Exp2(-now.Sub(proxy.scoredAt).Seconds()/TibiaDataProxyThrottleHalfLife.Seconds())
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+func TestProxyURLs(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(TibiaDataProxyURLs("", "https"))
+	assert.Equal([]string{
+		"https://proxy1.example.com/",
+		"http://proxy2.example.com:8080/",
+		"https://proxy3.example.com/",
+	}, TibiaDataProxyURLs("proxy1.example.com, http://proxy2.example.com:8080,https://proxy3.example.com/,", "https"))
+}
+
+func TestProxyPoolEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	pool := NewTibiaDataProxyPool(nil, TibiaDataProxyRoundRobin, 1, time.Second, time.Second)
+	proxy, err := pool.Pick(time.Now())
+	assert.Nil(proxy)
+	assert.Nil(err)
+
+	// requests without proxy are not recorded
+	pool.Done(proxy, time.Now(), "https://www.tibia.com/", http.StatusOK, nil)
+	assert.Empty(pool.Stats().Requests)
+}
+
+func TestProxyPoolRoundRobin(t *testing.T) {
+	assert := assert.New(t)
+
+	pool := NewTibiaDataProxyPool([]string{"https://a/", "https://b/", "https://c/"}, TibiaDataProxyRoundRobin, 1, time.Minute, time.Minute)
+	now := time.Now()
+
+	var picked []string
+	for i := 0; i < 4; i++ {
+		proxy, err := pool.Pick(now)
+		assert.Nil(err)
+		picked = append(picked, proxy.URL)
+		pool.Done(proxy, now, "https://www.tibia.com/", http.StatusOK, nil)
+	}
+	assert.Equal([]string{"https://a/", "https://b/", "https://c/", "https://a/"}, picked)
+
+	// the most recent request comes first
+	stats := pool.Stats()
+	assert.Len(stats.Requests, 4)
+	assert.Equal("https://a/", stats.Requests[0].Proxy)
+	assert.Equal("https://c/", stats.Requests[1].Proxy)
+	assert.Equal(int64(2), stats.Proxies[0].Requests)
+}
+
+func TestProxyPoolLeastThrottled(t *testing.T) {
+	assert := assert.New(t)
+
+	pool := NewTibiaDataProxyPool([]string{"https://a/", "https://b/"}, TibiaDataProxyLeastThrottled, 10, time.Minute, time.Minute)
+	now := time.Now()
+
+	a, _ := pool.Pick(now)
+	assert.Equal("https://a/", a.URL)
+	pool.Done(a, now, "https://www.tibia.com/", http.StatusForbidden, nil)
+
+	// the throttled proxy is avoided until its throttling has been forgotten
+	for i := 0; i < 3; i++ {
+		proxy, _ := pool.Pick(now)
+		assert.Equal("https://b/", proxy.URL)
+		pool.Done(proxy, now, "https://www.tibia.com/", http.StatusOK, nil)
+	}
+
+	b, _ := pool.Pick(now)
+	pool.Done(b, now, "https://www.tibia.com/", http.StatusForbidden, nil)
+	pool.Done(b, now, "https://www.tibia.com/", http.StatusForbidden, nil)
+
+	proxy, _ := pool.Pick(now)
+	assert.Equal("https://a/", proxy.URL)
+
+	stats := pool.Stats()
+	assert.Equal(int64(1), stats.Proxies[0].Throttled)
+	assert.Equal(int64(2), stats.Proxies[1].Throttled)
+	assert.InDelta(0.5, pool.proxies[0].throttleScore(now.Add(TibiaDataProxyThrottleHalfLife)), 0.001)
+}
+
+func TestProxyPoolEjection(t *testing.T) {
+	assert := assert.New(t)
+
+	pool := NewTibiaDataProxyPool([]string{"https://a/", "https://b/"}, TibiaDataProxyRoundRobin, 2, 10*time.Second, 15*time.Second)
+	now := time.Now()
+
+	a, _ := pool.Pick(now)
+	b, _ := pool.Pick(now)
+	pool.Done(a, now, "https://www.tibia.com/", 0, errors.New("timeout"))
+	pool.Done(a, now, "https://www.tibia.com/", http.StatusBadGateway, nil)
+	assert.Equal(TibiaDataProxyEjected, pool.Stats().Proxies[0].State)
+	assert.Equal("tibia.com responded with status 502", pool.Stats().Proxies[0].LastError)
+
+	// only the other proxy is used while the proxy is ejected
+	for i := 0; i < 3; i++ {
+		proxy, err := pool.Pick(now)
+		assert.Nil(err)
+		assert.Equal(b, proxy)
+	}
+
+	// all proxies are ejected
+	pool.Done(b, now, "https://www.tibia.com/", http.StatusForbidden, nil)
+	pool.Done(b, now.Add(time.Second), "https://www.tibia.com/", http.StatusForbidden, nil)
+	_, err := pool.Pick(now.Add(time.Second))
+	assert.True(errors.Is(err, validation.ErrorUpstreamProxiesEjected))
+	var unavailableErr TibiaDataUnavailableError
+	assert.True(errors.As(err, &unavailableErr))
+	assert.Equal(9*time.Second, unavailableErr.RetryAfter)
+
+	// one request is let through after the ejection
+	now = now.Add(10 * time.Second)
+	proxy, err := pool.Pick(now)
+	assert.Nil(err)
+	assert.Equal(a, proxy)
+	assert.Equal(TibiaDataProxyProbation, pool.state(a, now))
+	assert.True(a.trial)
+	_, err = pool.Pick(now)
+	assert.NotNil(err)
+
+	// the ejection doubles, but is capped
+	pool.Done(a, now, "https://www.tibia.com/", http.StatusServiceUnavailable, nil)
+	assert.Equal(now.Add(15*time.Second), a.ejectedUntil)
+
+	// the proxy is readmitted after a successful request
+	now = now.Add(15 * time.Second)
+	proxy, _ = pool.Pick(now)
+	assert.Equal(b, proxy)
+	proxy, _ = pool.Pick(now)
+	assert.Equal(a, proxy)
+	pool.Done(a, now, "https://www.tibia.com/", http.StatusOK, nil)
+
+	stats := pool.Stats().Proxies[0]
+	assert.Equal(TibiaDataProxyHealthy, stats.State)
+	assert.Equal(0, stats.ConsecutiveFailures)
+	assert.Equal(0, stats.Ejections)
+	assert.Equal(int64(3), stats.Failed)
+}
+
+func TestProxyPoolCollector(t *testing.T) {
+	assert := assert.New(t)
+
+	var healthy, throttled atomic.Int32
+	var broken atomic.Bool
+	healthyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		healthy.Add(1)
+		if broken.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer healthyServer.Close()
+	throttledServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		throttled.Add(1)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer throttledServer.Close()
+
+	proxies := TibiaDataProxies
+	TibiaDataProxies = NewTibiaDataProxyPool([]string{throttledServer.URL + "/", healthyServer.URL + "/"}, TibiaDataProxyRoundRobin, 1, time.Minute, time.Minute)
+	defer func() { TibiaDataProxies = proxies }()
+
+	breaker := TibiaDataBreaker
+	TibiaDataBreaker = NewTibiaDataCircuitBreaker(1, time.Minute, time.Minute)
+	defer func() { TibiaDataBreaker = breaker }()
+
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method:  resty.MethodGet,
+		URL:     "https://www.tibia.com/community/?subtopic=worlds",
+		RawBody: true,
+	}
+
+	_, err := TibiaDataHTMLDataCollector(tibiadataRequest)
+	assert.Equal(validation.ErrStatusForbidden, err)
+
+	// the failure of one proxy does not pause the requests through the other one
+	assert.Equal(TibiaDataCircuitClosed, TibiaDataBreaker.Stats().State)
+
+	// the throttled proxy is ejected, so all further requests are sent through the other one
+	for i := 0; i < 3; i++ {
+		data, err := TibiaDataHTMLDataCollector(tibiadataRequest)
+		assert.Nil(err)
+		assert.Equal("ok", data)
+	}
+	assert.Equal(int32(1), throttled.Load())
+	assert.Equal(int32(3), healthy.Load())
+
+	stats := TibiaDataProxies.Stats()
+	assert.Equal(TibiaDataProxyEjected, stats.Proxies[0].State)
+	assert.Equal(healthyServer.URL+"/", stats.Requests[0].Proxy)
+	assert.Equal(healthyServer.URL+"/community/?subtopic=worlds", stats.Requests[0].URL)
+	assert.Equal(http.StatusOK, stats.Requests[0].Status)
+	assert.Equal(http.StatusForbidden, stats.Requests[3].Status)
+
+	// once no proxy is healthy, the failures are failures of tibia.com
+	broken.Store(true)
+	_, err = TibiaDataHTMLDataCollector(tibiadataRequest)
+	assert.NotNil(err)
+	assert.Equal(TibiaDataCircuitOpen, TibiaDataBreaker.Stats().State)
+}
+
+func TestProxyPoolProbe(t *testing.T) {
+	assert := assert.New(t)
+
+	pool := NewTibiaDataProxyPool([]string{"https://a/", "https://b/"}, TibiaDataProxyRoundRobin, 1, 10*time.Second, time.Minute)
+	now := time.Now()
+
+	a, _ := pool.Pick(now)
+	pool.Done(a, now, "https://www.tibia.com/", http.StatusForbidden, nil)
+	b, _ := pool.Pick(now)
+	assert.True(pool.Alternative(a, now))
+	assert.False(pool.Alternative(b, now))
+
+	var probed []string
+	probe := func(proxy *TibiaDataProxy) (int, error) {
+		probed = append(probed, proxy.URL)
+		return http.StatusOK, nil
+	}
+
+	// proxies are not probed while they are ejected
+	pool.Probe(now.Add(5*time.Second), probe)
+	assert.Empty(probed)
+
+	// the probe readmits the proxy once the ejection has passed
+	pool.Probe(now.Add(10*time.Second), probe)
+	assert.Equal([]string{"https://a/"}, probed)
+	assert.Equal(TibiaDataProxyHealthy, pool.state(a, now.Add(10*time.Second)))
+	assert.False(a.trial)
+
+	// a proxy failing while the other one is ejected has no alternative
+	pool.Done(b, now, "https://www.tibia.com/", 0, errors.New("timeout"))
+	assert.True(pool.Alternative(b, now))
+	assert.False(pool.Alternative(a, now.Add(10*time.Second)))
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+// TibiaDataPriority is the class of a request to tibia.com deciding which queued request is sent first
+type TibiaDataPriority int
+
+const (
+	TibiaDataPriorityNormal TibiaDataPriority = iota // The default of all requests.
+	TibiaDataPriorityHigh                            // Requests users are waiting for the most (e.g. character lookups).
+	TibiaDataPriorityLow                             // Requests that are fine to wait (e.g. highscore crawling).
+)
+
+// tibiaDataPriorities - the priorities in the order queued requests are sent
+var tibiaDataPriorities = []TibiaDataPriority{TibiaDataPriorityHigh, TibiaDataPriorityNormal, TibiaDataPriorityLow}
+
+// String func - returns the name of the priority
+func (p TibiaDataPriority) String() string {
+	switch p {
+	case TibiaDataPriorityHigh:
+		return "high"
+	case TibiaDataPriorityLow:
+		return "low"
+	default:
+		return "normal"
+	}
+}
+
+// TibiaDataUnavailableError is returned if tibia.com is not requested right now (e.g. by the rate limiter)
+type TibiaDataUnavailableError struct {
+	Err        validation.Error // The reason why tibia.com is not requested.
+	RetryAfter time.Duration    // The estimated time until tibia.com could be requested again.
+}
+
+func (e TibiaDataUnavailableError) Error() string {
+	return e.Err.Error()
+}
+
+func (e TibiaDataUnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// tibiaDataPriorityKey is the context key of the priority of a request to tibia.com
+type tibiaDataPriorityKey struct{}
+
+// tibiaDataPriorityContext func - returns a context carrying the priority, so retries of the request keep it
+func tibiaDataPriorityContext(priority TibiaDataPriority) context.Context {
+	return context.WithValue(context.Background(), tibiaDataPriorityKey{}, priority)
+}
+
+// tibiaDataPriorityFromContext func - returns the priority of the context or the normal priority
+func tibiaDataPriorityFromContext(ctx context.Context) TibiaDataPriority {
+	priority, _ := ctx.Value(tibiaDataPriorityKey{}).(TibiaDataPriority)
+	return priority
+}
+
+// TibiaDataRateLimiterStats stores the state of the rate limiter
+type TibiaDataRateLimiterStats struct {
+	Enabled  bool           `json:"enabled"`  // Whether requests to tibia.com are limited.
+	Rate     float64        `json:"rate"`     // The requests per second allowed.
+	Burst    int            `json:"burst"`    // The requests allowed at once after being idle.
+	Tokens   float64        `json:"tokens"`   // The requests that can be sent right now.
+	Queued   map[string]int `json:"queued"`   // The requests waiting per priority.
+	Allowed  int64          `json:"allowed"`  // The number of requests sent.
+	Rejected int64          `json:"rejected"` // The number of requests that would have waited too long.
+}
+
+// TibiaDataRateLimiter is a token bucket pacing all requests to tibia.com
+// requests exceeding the rate are queued by priority for at most maxWait
+type TibiaDataRateLimiter struct {
+	rate    float64 // tokens per second (0 disables the limiter)
+	burst   float64
+	maxWait time.Duration
+
+	mu          sync.Mutex
+	tokens      float64
+	last        time.Time
+	queues      [3][]chan struct{} // waiting requests per priority
+	dispatching bool
+	allowed     int64
+	rejected    int64
+}
+
+// TibiaDataLimiter - the rate limiter used by TibiaDataHTMLDataCollector
+var TibiaDataLimiter = NewTibiaDataRateLimiter(0, 1, 0)
+
+// NewTibiaDataRateLimiter func - returns a rate limiter allowing rate requests per second with bursts of burst requests
+func NewTibiaDataRateLimiter(rate float64, burst int, maxWait time.Duration) *TibiaDataRateLimiter {
+	return &TibiaDataRateLimiter{
+		rate:    rate,
+		burst:   float64(max(burst, 1)),
+		maxWait: maxWait,
+		tokens:  float64(max(burst, 1)),
+		last:    time.Now(),
+	}
+}
+
+// Wait func - blocks until the request can be sent or returns TibiaDataUnavailableError if it would wait too long
+func (l *TibiaDataRateLimiter) Wait(priority TibiaDataPriority) error {
+	if l.rate <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.refill(now)
+
+	if l.queued() == 0 && l.tokens >= 1 {
+		l.tokens--
+		l.allowed++
+		l.mu.Unlock()
+		return nil
+	}
+
+	// requests of the same or a higher priority are sent first
+	if wait := l.estimate(priority); wait > l.maxWait {
+		l.rejected++
+		l.mu.Unlock()
+		return TibiaDataUnavailableError{Err: validation.ErrorUpstreamRateLimited, RetryAfter: wait}
+	}
+
+	ready := make(chan struct{})
+	l.queues[priority] = append(l.queues[priority], ready)
+	l.schedule()
+	l.mu.Unlock()
+
+	timer := time.NewTimer(l.maxWait)
+	defer timer.Stop()
+
+	select {
+	case <-ready:
+		return nil
+	case <-timer.C:
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// the request may have been dispatched in the meantime
+	select {
+	case <-ready:
+		return nil
+	default:
+	}
+
+	for i, waiting := range l.queues[priority] {
+		if waiting == ready {
+			l.queues[priority] = append(l.queues[priority][:i], l.queues[priority][i+1:]...)
+			break
+		}
+	}
+	l.rejected++
+
+	return TibiaDataUnavailableError{Err: validation.ErrorUpstreamRateLimited, RetryAfter: l.estimate(priority)}
+}
+
+// Stats func - returns the state of the rate limiter
+func (l *TibiaDataRateLimiter) Stats() TibiaDataRateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(time.Now())
+
+	queued := make(map[string]int, len(tibiaDataPriorities))
+	for _, priority := range tibiaDataPriorities {
+		queued[priority.String()] = len(l.queues[priority])
+	}
+
+	return TibiaDataRateLimiterStats{
+		Enabled:  l.rate > 0,
+		Rate:     l.rate,
+		Burst:    int(l.burst),
+		Tokens:   l.tokens,
+		Queued:   queued,
+		Allowed:  l.allowed,
+		Rejected: l.rejected,
+	}
+}
+
+// refill func - adds the tokens earned since the last refill (the lock must be held)
+func (l *TibiaDataRateLimiter) refill(now time.Time) {
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+}
+
+// queued func - returns the amount of waiting requests (the lock must be held)
+func (l *TibiaDataRateLimiter) queued() int {
+	queued := 0
+	for _, queue := range l.queues {
+		queued += len(queue)
+	}
+	return queued
+}
+
+// estimate func - returns how long a new request of the priority would wait (the lock must be held)
+func (l *TibiaDataRateLimiter) estimate(priority TibiaDataPriority) time.Duration {
+	ahead := 0
+	for _, p := range tibiaDataPriorities {
+		ahead += len(l.queues[p])
+		if p == priority {
+			break
+		}
+	}
+
+	return max(time.Duration((float64(ahead+1)-l.tokens)/l.rate*float64(time.Second)), 0)
+}
+
+// schedule func - dispatches the queued requests once the next token is available (the lock must be held)
+func (l *TibiaDataRateLimiter) schedule() {
+	if l.dispatching || l.queued() == 0 {
+		return
+	}
+
+	l.dispatching = true
+	time.AfterFunc(max(time.Duration((1-l.tokens)/l.rate*float64(time.Second)), 0), l.dispatch)
+}
+
+// dispatch func - releases queued requests by priority as long as tokens are available
+func (l *TibiaDataRateLimiter) dispatch() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.dispatching = false
+	l.refill(time.Now())
+
+	for _, priority := range tibiaDataPriorities {
+		for len(l.queues[priority]) > 0 && l.tokens >= 1 {
+			close(l.queues[priority][0])
+			l.queues[priority] = l.queues[priority][1:]
+			l.tokens--
+			l.allowed++
+		}
+	}
+
+	l.schedule()
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+func TestRateLimiterDisabled(t *testing.T) {
+	assert := assert.New(t)
+
+	limiter := NewTibiaDataRateLimiter(0, 1, 0)
+	for i := 0; i < 100; i++ {
+		assert.Nil(limiter.Wait(TibiaDataPriorityNormal))
+	}
+	assert.False(limiter.Stats().Enabled)
+}
+
+func TestRateLimiterBurst(t *testing.T) {
+	assert := assert.New(t)
+
+	limiter := NewTibiaDataRateLimiter(10, 2, time.Second)
+	start := time.Now()
+
+	// the burst is sent right away, the next request waits for a token
+	assert.Nil(limiter.Wait(TibiaDataPriorityNormal))
+	assert.Nil(limiter.Wait(TibiaDataPriorityNormal))
+	assert.Less(time.Since(start), 50*time.Millisecond)
+
+	assert.Nil(limiter.Wait(TibiaDataPriorityNormal))
+	assert.GreaterOrEqual(time.Since(start), 80*time.Millisecond)
+
+	stats := limiter.Stats()
+	assert.True(stats.Enabled)
+	assert.Equal(int64(3), stats.Allowed)
+	assert.Equal(int64(0), stats.Rejected)
+}
+
+func TestRateLimiterReject(t *testing.T) {
+	assert := assert.New(t)
+
+	limiter := NewTibiaDataRateLimiter(1, 1, 100*time.Millisecond)
+	assert.Nil(limiter.Wait(TibiaDataPriorityNormal))
+
+	// the next token is available in a second, which exceeds the max wait
+	err := limiter.Wait(TibiaDataPriorityNormal)
+	assert.True(errors.Is(err, validation.ErrorUpstreamRateLimited))
+
+	var rateLimitErr TibiaDataUnavailableError
+	assert.True(errors.As(err, &rateLimitErr))
+	assert.Greater(rateLimitErr.RetryAfter, 900*time.Millisecond)
+	assert.Equal(int64(1), limiter.Stats().Rejected)
+}
+
+func TestRateLimiterPriority(t *testing.T) {
+	assert := assert.New(t)
+
+	limiter := NewTibiaDataRateLimiter(5, 1, 2*time.Second)
+	assert.Nil(limiter.Wait(TibiaDataPriorityNormal))
+
+	var (
+		mu    sync.Mutex
+		order []TibiaDataPriority
+		wg    sync.WaitGroup
+	)
+
+	// queued requests are sent by priority, not in the order they were queued
+	for _, priority := range []TibiaDataPriority{TibiaDataPriorityLow, TibiaDataPriorityNormal, TibiaDataPriorityHigh} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			assert.Nil(limiter.Wait(priority))
+
+			mu.Lock()
+			order = append(order, priority)
+			mu.Unlock()
+		}()
+
+		// making sure the request is queued before the next one
+		assert.Eventually(func() bool {
+			return limiter.Stats().Queued[priority.String()] == 1
+		}, time.Second, time.Millisecond)
+	}
+
+	wg.Wait()
+	assert.Equal([]TibiaDataPriority{TibiaDataPriorityHigh, TibiaDataPriorityNormal, TibiaDataPriorityLow}, order)
+}
+
+func TestRateLimiterErrorHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	assert := assert.New(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	TibiaDataErrorHandler(c, TibiaDataUnavailableError{Err: validation.ErrorUpstreamRateLimited, RetryAfter: 1500 * time.Millisecond}, http.StatusBadGateway)
+	assert.Equal(http.StatusServiceUnavailable, w.Code)
+	assert.Equal("2", w.Header().Get("Retry-After"))
+	assert.Contains(w.Body.String(), `"error":12`)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// TibiaDataStaleTracker remembers which upstream requests are failing and refreshes them in the background
+type TibiaDataStaleTracker struct {
+	mu            sync.Mutex
+	failing       map[string]time.Time // key and time of the last failed attempt
+	refreshing    map[string]bool      // keys being refreshed right now
+	retryInterval time.Duration
+}
+
+var (
+	// TibiaDataStale - the tracker used by tibiaDataRequestHandler
+	TibiaDataStale = NewTibiaDataStaleTracker(10 * time.Second)
+
+	// TibiaDataCacheMaxStales - how long expired content can be served per handler name while tibia.com is failing
+	// can be overridden by env TIBIADATA_CACHE_MAX_STALES (e.g. TibiaWorldsWorld=5m,TibiaNews=24h)
+	TibiaDataCacheMaxStales = map[string]time.Duration{
+		"TibiaWorldsOverview":      10 * time.Minute, // players online are outdated quickly
+		"TibiaWorldsWorld":         10 * time.Minute,
+		"TibiaCharactersCharacter": 10 * time.Minute,
+		"TibiaSpellsOverview":      24 * time.Hour,
+		"TibiaSpellsSpell":         24 * time.Hour,
+		"TibiaCreaturesCreature":   24 * time.Hour,
+	}
+)
+
+// NewTibiaDataStaleTracker func - returns a tracker retrying failing requests at most once per retryInterval
+func NewTibiaDataStaleTracker(retryInterval time.Duration) *TibiaDataStaleTracker {
+	return &TibiaDataStaleTracker{
+		failing:       make(map[string]time.Time),
+		refreshing:    make(map[string]bool),
+		retryInterval: retryInterval,
+	}
+}
+
+// Failing func - reports whether the last request of the key failed
+func (t *TibiaDataStaleTracker) Failing(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, ok := t.failing[key]
+	return ok
+}
+
+// Failed func - marks the key as failing
+func (t *TibiaDataStaleTracker) Failed(key string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.failing[key] = now
+}
+
+// Recovered func - marks the key as working again
+func (t *TibiaDataStaleTracker) Recovered(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.failing, key)
+}
+
+// Refresh func - runs fn in the background unless the key is being refreshed or failed too recently
+// the key is marked as failing or recovered depending on the error returned by fn
+func (t *TibiaDataStaleTracker) Refresh(key string, now time.Time, fn func() error) bool {
+	t.mu.Lock()
+	if t.refreshing[key] || now.Sub(t.failing[key]) < t.retryInterval {
+		t.mu.Unlock()
+		return false
+	}
+	t.refreshing[key] = true
+	t.mu.Unlock()
+
+	go func() {
+		err := fn()
+
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		delete(t.refreshing, key)
+		if err != nil {
+			t.failing[key] = time.Now()
+		} else {
+			delete(t.failing, key)
+		}
+	}()
+
+	return true
+}
+
+// Purge func - forgets keys that failed before maxAge ago
+func (t *TibiaDataStaleTracker) Purge(now time.Time, maxAge time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, failed := range t.failing {
+		if now.Sub(failed) > maxAge {
+			delete(t.failing, key)
+		}
+	}
+}
+
+// tibiaDataCacheMaxStale func - returns how long expired content of the handler can be served
+func tibiaDataCacheMaxStale(handlerName string) time.Duration {
+	if maxStale, ok := TibiaDataCacheMaxStales[handlerName]; ok {
+		return maxStale
+	}
+
+	return TibiaDataCacheDefaultMaxStale
+}
+
+// tibiaDataStaleResponse func - marks the response as stale and sets its timestamp to when the content was fetched
+// responses without an Information field are returned unchanged
+func tibiaDataStaleResponse(jsonData interface{}, created time.Time) interface{} {
+	value := reflect.ValueOf(jsonData)
+	if value.Kind() != reflect.Struct {
+		return jsonData
+	}
+
+	// copying the response, so its fields can be set
+	response := reflect.New(value.Type()).Elem()
+	response.Set(value)
+
+	field := response.FieldByName("Information")
+	if !field.IsValid() || field.Type() != reflect.TypeOf(Information{}) {
+		return jsonData
+	}
+
+	information := field.Interface().(Information)
+	information.Timestamp = created.UTC().Format(time.RFC3339)
+	information.Status.Stale = true
+	field.Set(reflect.ValueOf(information))
+
+	return response.Interface()
+}
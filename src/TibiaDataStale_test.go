@@ -0,0 +1,207 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/static"
+)
+
+func TestStaleTracker(t *testing.T) {
+	assert := assert.New(t)
+
+	tracker := NewTibiaDataStaleTracker(time.Minute)
+	now := time.Now()
+
+	assert.False(tracker.Failing("key"))
+	tracker.Failed("key", now)
+	assert.True(tracker.Failing("key"))
+	tracker.Recovered("key")
+	assert.False(tracker.Failing("key"))
+
+	// only one refresh runs at a time
+	release := make(chan struct{})
+	done := make(chan struct{})
+	assert.True(tracker.Refresh("key", now, func() error {
+		<-release
+		defer close(done)
+		return errors.New("maintenance")
+	}))
+	assert.False(tracker.Refresh("key", now, func() error { return nil }))
+	close(release)
+	<-done
+
+	// waiting for the refresh to be finished
+	assert.Eventually(func() bool { return tracker.Failing("key") }, time.Second, time.Millisecond)
+
+	// failed keys are retried after the interval only
+	assert.False(tracker.Refresh("key", time.Now(), func() error { return nil }))
+	assert.True(tracker.Refresh("key", time.Now().Add(time.Minute), func() error { return nil }))
+	assert.Eventually(func() bool { return !tracker.Failing("key") }, time.Second, time.Millisecond)
+
+	tracker.Failed("old", now.Add(-2*time.Hour))
+	tracker.Failed("new", now)
+	tracker.Purge(now, time.Hour)
+	assert.False(tracker.Failing("old"))
+	assert.True(tracker.Failing("new"))
+}
+
+func TestStaleResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	response := FansitesResponse{Information: Information{Timestamp: "now", Status: Status{HTTPCode: http.StatusOK}}}
+
+	stale, ok := tibiaDataStaleResponse(response, created).(FansitesResponse)
+	assert.True(ok)
+	assert.True(stale.Information.Status.Stale)
+	assert.Equal("2024-05-01T12:00:00Z", stale.Information.Timestamp)
+	assert.Equal(http.StatusOK, stale.Information.Status.HTTPCode)
+
+	// the original response is left untouched
+	assert.False(response.Information.Status.Stale)
+
+	assert.Equal(gin.H{"a": 1}, tibiaDataStaleResponse(gin.H{"a": 1}, created))
+}
+
+func TestStaleRequestHandler(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/fansites/all.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	assert := assert.New(t)
+
+	// tibia.com is in maintenance mode
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Redirect(w, r, "https://maintenance.tibia.com/", http.StatusFound)
+	}))
+	defer server.Close()
+
+	proxies := TibiaDataProxies
+	TibiaDataProxies = NewTibiaDataProxyPool([]string{server.URL + "/"}, TibiaDataProxyRoundRobin, 100, time.Minute, time.Minute)
+	defer func() { TibiaDataProxies = proxies }()
+
+	cache := TibiaDataCache
+	TibiaDataCache = NewTibiaDataCacheMemory(10)
+	defer func() { TibiaDataCache = cache }()
+
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method: resty.MethodGet,
+		URL:    "https://www.tibia.com/community/?subtopic=fansites",
+	}
+	cacheKey := tibiaDataCacheKey(tibiadataRequest)
+	defer TibiaDataStale.Recovered(cacheKey)
+
+	// without content to fall back to, the error is returned
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	tibiaFansites(c)
+	assert.Equal(http.StatusBadGateway, w.Code)
+	assert.NotZero(requests.Load())
+
+	created := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	assert.Nil(TibiaDataCache.Set(cacheKey, TibiaDataCacheEntry{
+		Content:    string(data),
+		Created:    created,
+		Expires:    time.Now().Add(-time.Hour),
+		StaleUntil: time.Now().Add(time.Hour),
+	}))
+
+	// the expired content is served after tibia.com failed
+	failedRequests := requests.Load()
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	tibiaFansites(c)
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Greater(requests.Load(), failedRequests)
+	assert.Equal("public, max-age=0", w.Header().Get("Cache-Control"))
+	assert.Contains(w.Body.String(), `"stale":true`)
+	assert.Contains(w.Body.String(), `"timestamp":"`+created.UTC().Format(time.RFC3339)+`"`)
+	assert.True(TibiaDataStale.Failing(cacheKey))
+
+	// tibia.com is not requested again until the retry interval has passed
+	failedRequests = requests.Load()
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	tibiaFansites(c)
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal(failedRequests, requests.Load())
+	assert.Contains(w.Body.String(), `"stale":true`)
+}
+
+func TestStaleRequestHandlerUnavailable(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/fansites/all.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	assert := assert.New(t)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	proxies := TibiaDataProxies
+	TibiaDataProxies = NewTibiaDataProxyPool([]string{server.URL + "/"}, TibiaDataProxyRoundRobin, 100, time.Minute, time.Minute)
+	defer func() { TibiaDataProxies = proxies }()
+
+	cache := TibiaDataCache
+	TibiaDataCache = NewTibiaDataCacheMemory(10)
+	defer func() { TibiaDataCache = cache }()
+
+	// the circuit breaker rejects the request before it is sent to tibia.com
+	breaker := TibiaDataBreaker
+	TibiaDataBreaker = NewTibiaDataCircuitBreaker(1, time.Hour, time.Hour)
+	TibiaDataBreaker.Failure(time.Now(), nil)
+	defer func() { TibiaDataBreaker = breaker }()
+
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method: resty.MethodGet,
+		URL:    "https://www.tibia.com/community/?subtopic=fansites",
+	}
+	cacheKey := tibiaDataCacheKey(tibiadataRequest)
+	defer TibiaDataStale.Recovered(cacheKey)
+
+	assert.Nil(TibiaDataCache.Set(cacheKey, TibiaDataCacheEntry{
+		Content:    string(data),
+		Created:    time.Now().Add(-2 * time.Hour),
+		Expires:    time.Now().Add(-time.Hour),
+		StaleUntil: time.Now().Add(time.Hour),
+	}))
+
+	// the expired content is served, but tibia.com is not marked as failing
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	tibiaFansites(c)
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Contains(w.Body.String(), `"stale":true`)
+	assert.Zero(requests.Load())
+	assert.False(TibiaDataStale.Failing(cacheKey))
+}
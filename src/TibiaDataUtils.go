@@ -11,6 +11,7 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/PuerkitoBio/goquery"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	"golang.org/x/text/unicode/norm"
@@ -40,6 +41,11 @@ func TibiaDataDatetime(date string) string {
 		// parsing html in time with location set in loc
 		returnDate, err = time.ParseInLocation(formatting, date, loc)
 
+		// some pages (e.g. the char bazaar) only show minutes: Jan 02 2007, 19:20 CET
+		if err != nil {
+			returnDate, err = time.ParseInLocation("Jan 02 2006, 15:04 MST", date, loc)
+		}
+
 		// parsing html in tiem without loc
 		//returnDate, err = time.Parse("Jan 02 2006, 15:04:05 MST", date)
 
@@ -52,6 +58,39 @@ func TibiaDataDatetime(date string) string {
 	return returnDate.UTC().Format(time.RFC3339)
 }
 
+var pageNavigationResultsRegex = regexp.MustCompile(`Results: ([0-9,]+)`)
+
+// TibiaDataPageNavigation func - returns current page, total pages and total results of the first page navigation found
+func TibiaDataPageNavigation(s *goquery.Selection) (int, int, int) {
+	var CurrentPage, TotalPages, TotalResults int
+
+	PageNavigation := s.Find(".PageNavigation").First()
+
+	// the current page is not a link
+	CurrentPage = TibiaDataStringToInteger(PageNavigation.Find(".CurrentPageLink").First().Text())
+	TotalPages = CurrentPage
+
+	// the last page is the highest page linked to (including the "Last Page" link)
+	PageNavigation.Find(".PageLink a").Each(func(index int, s *goquery.Selection) {
+		PageURL, _ := s.Attr("href")
+		p, err := url.Parse(PageURL)
+		if err != nil {
+			return
+		}
+
+		if linkedPage := TibiaDataStringToInteger(p.Query().Get("currentpage")); linkedPage > TotalPages {
+			TotalPages = linkedPage
+		}
+	})
+
+	subma1 := pageNavigationResultsRegex.FindStringSubmatch(TibiaDataSanitizeStrings(PageNavigation.Text()))
+	if len(subma1) > 0 {
+		TotalResults = TibiaDataStringToInteger(subma1[1])
+	}
+
+	return CurrentPage, TotalPages, TotalResults
+}
+
 // TibiaDataHTMLRemoveLinebreaks func
 func TibiaDataHTMLRemoveLinebreaks(data string) string {
 	return strings.ReplaceAll(data, "\n", "")
@@ -237,6 +276,57 @@ func getEnvAsBool(name string, defaultVal bool) bool {
 	return defaultVal
 }
 
+// getEnvAsInt func - read an environment variable into an int or return default value
+// a value that is set but cannot be parsed is logged, so a typo does not go unnoticed
+func getEnvAsInt(name string, defaultVal int) int {
+	valStr := getEnv(name, "")
+	if valStr == "" {
+		return defaultVal
+	}
+
+	val, err := strconv.Atoi(valStr)
+	if err != nil {
+		log.Printf("[warning] TibiaData API env %s is invalid, using %d: %s", name, defaultVal, err)
+		return defaultVal
+	}
+
+	return val
+}
+
+// getEnvAsFloat func - read an environment variable into a float64 or return default value
+// a value that is set but cannot be parsed is logged, so a typo does not go unnoticed
+func getEnvAsFloat(name string, defaultVal float64) float64 {
+	valStr := getEnv(name, "")
+	if valStr == "" {
+		return defaultVal
+	}
+
+	val, err := strconv.ParseFloat(valStr, 64)
+	if err != nil {
+		log.Printf("[warning] TibiaData API env %s is invalid, using %v: %s", name, defaultVal, err)
+		return defaultVal
+	}
+
+	return val
+}
+
+// getEnvAsDuration func - read an environment variable into a time.Duration or return default value
+// a value that is set but cannot be parsed is logged, so a typo does not go unnoticed
+func getEnvAsDuration(name string, defaultVal time.Duration) time.Duration {
+	valStr := getEnv(name, "")
+	if valStr == "" {
+		return defaultVal
+	}
+
+	val, err := time.ParseDuration(valStr)
+	if err != nil {
+		log.Printf("[warning] TibiaData API env %s is invalid, using %s: %s", name, defaultVal, err)
+		return defaultVal
+	}
+
+	return val
+}
+
 // TibiaDataConvertValuesWithK func - convert price strings that contain k, kk or more to 3x0
 func TibiaDataConvertValuesWithK(data string) int {
 	return TibiaDataStringToInteger(strings.ReplaceAll(data, "k", "") + strings.Repeat("000", strings.Count(data, "k")))
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"bytes"
+	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -19,6 +22,10 @@ func TestTibiaUTCDateFormat(t *testing.T) {
 	assert.Equal(t, "2021-12-24T09:52:16Z", TibiaDataDatetime("Dec 24 2021, 09:52:16 UTC"))
 }
 
+func TestTibiaWithoutSecondsDateFormat(t *testing.T) {
+	assert.Equal(t, "2024-03-21T09:00:00Z", TibiaDataDatetime("Mar 21 2024, 10:00 CET"))
+}
+
 func TestIsEnvExist(t *testing.T) {
 	assert := assert.New(t)
 
@@ -66,6 +73,67 @@ func TestGetEnvAsBool(t *testing.T) {
 	os.Unsetenv("TIBIADATA_ENV")
 }
 
+func TestGetEnvAsInt(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test when environment variable is not set
+	assert.Equal(10, getEnvAsInt("TIBIADATA_ENV", 10))
+
+	// Test when environment variable is set to a number
+	os.Setenv("TIBIADATA_ENV", "25")
+	assert.Equal(25, getEnvAsInt("TIBIADATA_ENV", 10))
+
+	// Test when environment variable is not a number
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	os.Setenv("TIBIADATA_ENV", "many")
+	assert.Equal(10, getEnvAsInt("TIBIADATA_ENV", 10))
+	assert.Contains(logs.String(), "[warning] TibiaData API env TIBIADATA_ENV is invalid, using 10")
+
+	os.Unsetenv("TIBIADATA_ENV")
+}
+
+func TestGetEnvAsFloat(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test when environment variable is not set
+	assert.Equal(1.5, getEnvAsFloat("TIBIADATA_ENV", 1.5))
+
+	// Test when environment variable is set to a number
+	os.Setenv("TIBIADATA_ENV", "0.25")
+	assert.Equal(0.25, getEnvAsFloat("TIBIADATA_ENV", 1.5))
+
+	// Test when environment variable is not a number
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	os.Setenv("TIBIADATA_ENV", "fast")
+	assert.Equal(1.5, getEnvAsFloat("TIBIADATA_ENV", 1.5))
+	assert.Contains(logs.String(), "[warning] TibiaData API env TIBIADATA_ENV is invalid, using 1.5")
+
+	os.Unsetenv("TIBIADATA_ENV")
+}
+
+func TestGetEnvAsDuration(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test when environment variable is not set
+	assert.Equal(time.Minute, getEnvAsDuration("TIBIADATA_ENV", time.Minute))
+
+	// Test when environment variable is set to a duration
+	os.Setenv("TIBIADATA_ENV", "90s")
+	assert.Equal(90*time.Second, getEnvAsDuration("TIBIADATA_ENV", time.Minute))
+
+	// Test when environment variable is not a duration
+	os.Setenv("TIBIADATA_ENV", "10")
+	assert.Equal(time.Minute, getEnvAsDuration("TIBIADATA_ENV", time.Minute))
+
+	os.Unsetenv("TIBIADATA_ENV")
+}
+
 func TestTibiaDataVocationValidator(t *testing.T) {
 	assert := assert.New(t)
 
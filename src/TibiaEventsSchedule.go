@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	_ "time/tzdata" // the timezone of tibia.com is also needed on images without tzdata
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Child of EventsSchedule
+type ScheduleEvent struct {
+	Name        string `json:"name"`        // The name of the event.
+	Description string `json:"description"` // The description of the event.
+	StartDate   string `json:"start_date"`  // The timestamp when the event starts. (server save)
+	EndDate     string `json:"end_date"`    // The timestamp when the event ends. (server save)
+}
+
+// Child of JSONData
+type EventsSchedule struct {
+	Year   int             `json:"year"`   // The year of the event schedule.
+	Month  int             `json:"month"`  // The month of the event schedule.
+	Events []ScheduleEvent `json:"events"` // List of events shown in the event schedule.
+}
+
+// The base includes two levels: EventsSchedule and Information
+type EventsScheduleResponse struct {
+	EventsSchedule EventsSchedule `json:"events_schedule"`
+	Information    Information    `json:"information"`
+}
+
+var (
+	// TibiaEventsScheduleLocation - the timezone of the event schedule of tibia.com (CET/CEST)
+	TibiaEventsScheduleLocation = tibiaEventsScheduleLocation()
+
+	EventsScheduleTooltipRegex = regexp.MustCompile(`ActivateHelperDiv\(\$\(this\), '.*?', '(.*)', ''\);`)
+)
+
+// tibiaEventsScheduleLocation func - returns the timezone of tibia.com, which is embedded through time/tzdata
+func tibiaEventsScheduleLocation() *time.Location {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		// time/tzdata is embedded, so this only happens with a broken build
+		panic(err)
+	}
+
+	return loc
+}
+
+func TibiaEventsScheduleImpl(year int, month int, BoxContentHTML string, url string) (EventsScheduleResponse, error) {
+	// Creating empty vars
+	var (
+		EventsData       []ScheduleEvent
+		eventsLastDay    = map[string]time.Time{}
+		eventsIndex      = map[string]int{}
+		monthOffset      int
+		previousDay      int
+		firstDayOfMonth  = true
+		serverSaveHour   = 10
+		eventStartMarker = "*"
+	)
+
+	// Loading HTML data into ReaderHTML for goquery with NewReader
+	ReaderHTML, err := goquery.NewDocumentFromReader(strings.NewReader(BoxContentHTML))
+	if err != nil {
+		return EventsScheduleResponse{}, fmt.Errorf("[error] TibiaEventsScheduleImpl failed at goquery.NewDocumentFromReader, err: %s", err)
+	}
+
+	// Running query over each day of the calendar
+	ReaderHTML.Find("#eventscheduletable td").Each(func(index int, s *goquery.Selection) {
+		day := TibiaDataStringToInteger(strings.TrimSpace(s.Children().First().Text()))
+		if day == 0 {
+			// the weekday headline
+			return
+		}
+
+		// the calendar also shows the last days of the previous and the first days of the next month
+		if firstDayOfMonth {
+			firstDayOfMonth = false
+			if day != 1 {
+				monthOffset = -1
+			}
+		} else if day < previousDay {
+			monthOffset++
+		}
+		previousDay = day
+
+		date := time.Date(year, time.Month(month+monthOffset), day, serverSaveHour, 0, 0, 0, TibiaEventsScheduleLocation)
+
+		s.Find(".HelperDivIndicator").Each(func(index int, s *goquery.Selection) {
+			name := strings.TrimPrefix(strings.TrimSpace(TibiaDataSanitizeStrings(s.Text())), eventStartMarker)
+
+			// an event that was shown the day before continues
+			if lastDay, ok := eventsLastDay[name]; ok && lastDay.AddDate(0, 0, 1).Equal(date) {
+				eventsLastDay[name] = date
+				EventsData[eventsIndex[name]].EndDate = date.AddDate(0, 0, 1).UTC().Format(time.RFC3339)
+				return
+			}
+
+			tooltip, _ := s.Attr("onmouseover")
+			eventsLastDay[name] = date
+			eventsIndex[name] = len(EventsData)
+			EventsData = append(EventsData, ScheduleEvent{
+				Name:        name,
+				Description: tibiaEventsScheduleDescription(tooltip),
+				StartDate:   date.UTC().Format(time.RFC3339),
+				EndDate:     date.AddDate(0, 0, 1).UTC().Format(time.RFC3339),
+			})
+		})
+	})
+
+	//
+	// Build the data-blob
+	return EventsScheduleResponse{
+		EventsSchedule{
+			Year:   year,
+			Month:  month,
+			Events: EventsData,
+		},
+		Information{
+			APIDetails: TibiaDataAPIDetails,
+			Timestamp:  TibiaDataDatetime(""),
+			TibiaURLs:  []string{url},
+			Status: Status{
+				HTTPCode: http.StatusOK,
+			},
+		},
+	}, nil
+}
+
+// tibiaEventsScheduleDescription func - extracts the plain text description from the tooltip of an event
+func tibiaEventsScheduleDescription(tooltip string) string {
+	subma1 := EventsScheduleTooltipRegex.FindStringSubmatch(tooltip)
+	if len(subma1) == 0 {
+		return ""
+	}
+
+	ReaderHTML, err := goquery.NewDocumentFromReader(strings.NewReader(strings.ReplaceAll(subma1[1], `\'`, "'")))
+	if err != nil {
+		return ""
+	}
+
+	// the tooltip starts with the name of the event
+	ReaderHTML.Find("b").First().Remove()
+
+	return strings.TrimSpace(TibiaDataSanitizeStrings(ReaderHTML.Text()))
+}
+
+// TibiaEventsScheduleActive func - returns the events that are running at the given time
+func TibiaEventsScheduleActive(events []ScheduleEvent, now time.Time) []ScheduleEvent {
+	var ActiveEvents []ScheduleEvent
+
+	for _, event := range events {
+		start, err := time.Parse(time.RFC3339, event.StartDate)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse(time.RFC3339, event.EndDate)
+		if err != nil {
+			continue
+		}
+
+		if !now.Before(start) && now.Before(end) {
+			ActiveEvents = append(ActiveEvents, event)
+		}
+	}
+
+	return ActiveEvents
+}
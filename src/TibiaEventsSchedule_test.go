@@ -0,0 +1,110 @@
+package main
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/static"
+)
+
+func TestEventsScheduleMarch2024(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/events/2024-03.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	eventsJson, err := TibiaEventsScheduleImpl(2024, 3, string(data), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	schedule := eventsJson.EventsSchedule
+
+	assert.Equal(2024, schedule.Year)
+	assert.Equal(3, schedule.Month)
+	assert.Equal(4, len(schedule.Events))
+
+	// continues from the days of february shown in the calendar
+	rapidRespawn := schedule.Events[0]
+	assert.Equal("Rapid Respawn", rapidRespawn.Name)
+	assert.Equal("All creatures respawn faster than usual.", rapidRespawn.Description)
+	assert.Equal("2024-02-29T09:00:00Z", rapidRespawn.StartDate)
+	assert.Equal("2024-03-04T09:00:00Z", rapidRespawn.EndDate)
+
+	doubleXP := schedule.Events[1]
+	assert.Equal("Double XP and Skill", doubleXP.Name)
+	assert.Equal("Gain double experience and skill progress. Don't miss it!", doubleXP.Description)
+	assert.Equal("2024-03-08T09:00:00Z", doubleXP.StartDate)
+	assert.Equal("2024-03-12T09:00:00Z", doubleXP.EndDate)
+
+	// ends after the switch to summer time
+	chyllfroest := schedule.Events[2]
+	assert.Equal("Chyllfroest", chyllfroest.Name)
+	assert.Equal("2024-03-15T09:00:00Z", chyllfroest.StartDate)
+	assert.Equal("2024-04-01T08:00:00Z", chyllfroest.EndDate)
+
+	bewitched := schedule.Events[3]
+	assert.Equal("Bewitched", bewitched.Name)
+	assert.Equal("2024-03-22T09:00:00Z", bewitched.StartDate)
+	assert.Equal("2024-03-23T09:00:00Z", bewitched.EndDate)
+
+	active := TibiaEventsScheduleActive(schedule.Events, time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC))
+	assert.Equal(1, len(active))
+	assert.Equal("Chyllfroest", active[0].Name)
+
+	active = TibiaEventsScheduleActive(schedule.Events, time.Date(2024, 3, 22, 9, 0, 0, 0, time.UTC))
+	assert.Equal(2, len(active))
+	assert.Equal("Bewitched", active[1].Name)
+
+	active = TibiaEventsScheduleActive(schedule.Events, time.Date(2024, 3, 12, 9, 0, 0, 0, time.UTC))
+	assert.Nil(active)
+}
+
+func TestEventsScheduleLocation(t *testing.T) {
+	// the timezone is embedded, so it does not depend on the tzdata of the system
+	assert.Equal(t, "Europe/Berlin", TibiaEventsScheduleLocation.String())
+	_, offset := time.Date(2024, 7, 1, 0, 0, 0, 0, TibiaEventsScheduleLocation).Zone()
+	assert.Equal(t, 2*60*60, offset)
+}
+
+func TestEventsScheduleLive(t *testing.T) {
+	url := "https://www.tibia.com/news/?subtopic=eventcalendar&calendarmonth=3&calendaryear=2024"
+	data := tibiaDataLivePage(t, url, "events/2024-03.html")
+
+	scheduleJson, err := TibiaEventsScheduleImpl(2024, 3, data, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	schedule := scheduleJson.EventsSchedule
+
+	assert.Equal(2024, schedule.Year)
+	assert.Equal(3, schedule.Month)
+	assert.NotEmpty(schedule.Events)
+
+	// every event is shown in the month, so it starts before the month ends and ends after it starts
+	monthStart := time.Date(2024, time.March, 1, 0, 0, 0, 0, TibiaEventsScheduleLocation)
+	monthEnd := monthStart.AddDate(0, 1, 0)
+	for _, event := range schedule.Events {
+		assert.NotEmpty(event.Name)
+
+		start, err := time.Parse(time.RFC3339, event.StartDate)
+		assert.Nil(err, event.Name)
+		end, err := time.Parse(time.RFC3339, event.EndDate)
+		assert.Nil(err, event.Name)
+
+		assert.False(end.Before(start), event.Name)
+		assert.True(start.Before(monthEnd), event.Name)
+		assert.True(end.After(monthStart), event.Name)
+	}
+}
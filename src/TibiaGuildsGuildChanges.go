@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GuildChangeType is the kind of a change between two snapshots of a guild
+type GuildChangeType string
+
+const (
+	GuildChangeJoined          GuildChangeType = "joined"
+	GuildChangeLeft            GuildChangeType = "left"
+	GuildChangeRankChanged     GuildChangeType = "rank_changed"
+	GuildChangeTitleChanged    GuildChangeType = "title_changed"
+	GuildChangeLevelChanged    GuildChangeType = "level_changed"
+	GuildChangeVocationChanged GuildChangeType = "vocation_changed"
+)
+
+// Child of GuildChanges
+type GuildChange struct {
+	Timestamp string          `json:"timestamp"`      // The time of the snapshot the change was noticed in.
+	Type      GuildChangeType `json:"type"`           // The type of the change.
+	Character string          `json:"character"`      // The member the change is about.
+	From      string          `json:"from,omitempty"` // The value before the change. (rank, title, level or vocation)
+	To        string          `json:"to,omitempty"`   // The value after the change. (rank, title, level or vocation)
+}
+
+// Child of JSONData
+type GuildChanges struct {
+	Name      string        `json:"name"`      // The name of the guild.
+	Since     string        `json:"since"`     // The time changes are shown since.
+	Snapshots int           `json:"snapshots"` // The number of snapshots taken since.
+	Changes   []GuildChange `json:"changes"`   // List of changes, oldest first.
+}
+
+// The base includes two levels: GuildChanges and Information
+type GuildChangesResponse struct {
+	GuildChanges GuildChanges `json:"guild_changes"`
+	Information  Information  `json:"information"`
+}
+
+// TibiaGuildsGuildChangesImpl func - compares consecutive recorded snapshots of the guild since the time
+// a renamed member shows up as one member leaving and another one joining
+func TibiaGuildsGuildChangesImpl(name string, since, until time.Time) (GuildChangesResponse, error) {
+	changes := GuildChanges{
+		Name:    name,
+		Since:   since.UTC().Format(time.RFC3339),
+		Changes: []GuildChange{},
+	}
+
+	// the snapshots are streamed, only the members of the previous one are kept
+	// the last snapshot before since is needed to compare the first one after it
+	var (
+		previous    []GuildMember
+		hasPrevious bool
+	)
+	err := TibiaDataHistory.Each("guild", name, until, func(snapshot TibiaDataSnapshot, created time.Time) bool {
+		var guild Guild
+		if json.Unmarshal(snapshot.Data, &guild) != nil {
+			return true
+		}
+
+		if created.After(since) {
+			if hasPrevious {
+				changes.Changes = append(changes.Changes, tibiaGuildsGuildDiff(snapshot.Timestamp, previous, guild.Members)...)
+			}
+			changes.Snapshots++
+		}
+
+		changes.Name = guild.Name
+		previous, hasPrevious = guild.Members, true
+		return true
+	})
+	if err != nil {
+		return GuildChangesResponse{}, err
+	}
+
+	//
+	// Build the data-blob
+	return GuildChangesResponse{
+		changes,
+		Information{
+			APIDetails: TibiaDataAPIDetails,
+			Timestamp:  TibiaDataDatetime(""),
+			TibiaURLs:  []string{},
+			Status: Status{
+				HTTPCode: http.StatusOK,
+			},
+		},
+	}, nil
+}
+
+// tibiaGuildsGuildDiff func - returns the changes between the members of two snapshots
+// changes are in the order of the roster, members who left last
+func tibiaGuildsGuildDiff(timestamp string, before, after []GuildMember) []GuildChange {
+	members := make(map[string]GuildMember, len(before))
+	for _, member := range before {
+		members[strings.ToLower(member.Name)] = member
+	}
+
+	var changes []GuildChange
+	add := func(changeType GuildChangeType, name, from, to string) {
+		changes = append(changes, GuildChange{Timestamp: timestamp, Type: changeType, Character: name, From: from, To: to})
+	}
+
+	for _, member := range after {
+		key := strings.ToLower(member.Name)
+		old, ok := members[key]
+		if !ok {
+			add(GuildChangeJoined, member.Name, "", member.Rank)
+			continue
+		}
+		delete(members, key)
+
+		if old.Rank != member.Rank {
+			add(GuildChangeRankChanged, member.Name, old.Rank, member.Rank)
+		}
+		if old.Title != member.Title {
+			add(GuildChangeTitleChanged, member.Name, old.Title, member.Title)
+		}
+		if old.Level != member.Level {
+			add(GuildChangeLevelChanged, member.Name, strconv.Itoa(old.Level), strconv.Itoa(member.Level))
+		}
+		if old.Vocation != member.Vocation {
+			add(GuildChangeVocationChanged, member.Name, old.Vocation, member.Vocation)
+		}
+	}
+
+	for _, member := range before {
+		if _, ok := members[strings.ToLower(member.Name)]; ok {
+			add(GuildChangeLeft, member.Name, member.Rank, "")
+		}
+	}
+
+	return changes
+}
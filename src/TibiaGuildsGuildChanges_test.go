@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuildChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	history := TibiaDataHistory
+	var err error
+	TibiaDataHistory, err = NewTibiaDataHistoryStore(t.TempDir(), time.Hour, 0)
+	assert.Nil(err)
+	defer func() { TibiaDataHistory = history }()
+
+	save := func(timestamp string, members ...GuildMember) {
+		raw, err := json.Marshal(Guild{Name: "Elysium", Members: members})
+		assert.Nil(err)
+		assert.Nil(TibiaDataHistory.Save("guild", "Elysium", TibiaDataSnapshot{Timestamp: timestamp, Data: raw}))
+	}
+
+	save("2026-01-01T00:00:00Z",
+		GuildMember{Name: "Trollefar", Rank: "Leader", Vocation: "Knight", Level: 100},
+		GuildMember{Name: "Bobeek", Rank: "Member", Vocation: "Druid", Level: 50})
+	save("2026-01-02T00:00:00Z",
+		GuildMember{Name: "Trollefar", Rank: "Leader", Title: "Boss", Vocation: "Elite Knight", Level: 101},
+		GuildMember{Name: "Bobeek", Rank: "Vice Leader", Vocation: "Druid", Level: 50},
+		GuildMember{Name: "Goraca", Rank: "Member", Vocation: "Sorcerer", Level: 20})
+	save("2026-01-03T00:00:00Z",
+		GuildMember{Name: "Trollefar", Rank: "Leader", Title: "Boss", Vocation: "Elite Knight", Level: 101},
+		GuildMember{Name: "Goraca", Rank: "Member", Vocation: "Sorcerer", Level: 20})
+
+	// the snapshot before since is compared to the first one after it
+	response, err := TibiaGuildsGuildChangesImpl("elysium", time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC), time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	assert.Nil(err)
+
+	changes := response.GuildChanges
+	assert.Equal("Elysium", changes.Name)
+	assert.Equal("2026-01-01T12:00:00Z", changes.Since)
+	assert.Equal(2, changes.Snapshots)
+	assert.Equal([]GuildChange{
+		{Timestamp: "2026-01-02T00:00:00Z", Type: GuildChangeTitleChanged, Character: "Trollefar", To: "Boss"},
+		{Timestamp: "2026-01-02T00:00:00Z", Type: GuildChangeLevelChanged, Character: "Trollefar", From: "100", To: "101"},
+		{Timestamp: "2026-01-02T00:00:00Z", Type: GuildChangeVocationChanged, Character: "Trollefar", From: "Knight", To: "Elite Knight"},
+		{Timestamp: "2026-01-02T00:00:00Z", Type: GuildChangeRankChanged, Character: "Bobeek", From: "Member", To: "Vice Leader"},
+		{Timestamp: "2026-01-02T00:00:00Z", Type: GuildChangeJoined, Character: "Goraca", To: "Member"},
+		{Timestamp: "2026-01-03T00:00:00Z", Type: GuildChangeLeft, Character: "Bobeek", From: "Vice Leader"},
+	}, changes.Changes)
+
+	// the first snapshot has nothing to be compared to
+	response, err = TibiaGuildsGuildChangesImpl("Elysium", time.Time{}, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Nil(err)
+	assert.Equal(1, response.GuildChanges.Snapshots)
+	assert.Empty(response.GuildChanges.Changes)
+
+	// guilds without snapshots have no changes
+	response, err = TibiaGuildsGuildChangesImpl("Unknown", time.Time{}, time.Now())
+	assert.Nil(err)
+	assert.Equal("Unknown", response.GuildChanges.Name)
+	assert.Equal(0, response.GuildChanges.Snapshots)
+	assert.Empty(response.GuildChanges.Changes)
+}
+
+func TestGuildChangesHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	assert := assert.New(t)
+
+	history := TibiaDataHistory
+	var err error
+	TibiaDataHistory, err = NewTibiaDataHistoryStore(t.TempDir(), time.Hour, 0)
+	assert.Nil(err)
+	defer func() { TibiaDataHistory = history }()
+
+	now := time.Now()
+	TibiaDataHistory.Record("TibiaGuildsGuild", GuildResponse{Guild: Guild{Name: "Elysium", Members: []GuildMember{{Name: "Trollefar", Rank: "Leader"}}}}, now.Add(-2*time.Hour))
+	TibiaDataHistory.Record("TibiaGuildsGuild", GuildResponse{Guild: Guild{Name: "Elysium", Members: []GuildMember{{Name: "Trollefar", Rank: "Leader"}, {Name: "Bobeek", Rank: "Member"}}}}, now.Add(-time.Hour))
+
+	assert.Eventually(func() bool {
+		snapshots, _ := TibiaDataHistory.Query("guild", "Elysium", time.Time{}, now, 0)
+		return len(snapshots) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	request := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+		c.Params = []gin.Param{{Key: "name", Value: "Elysium"}}
+		tibiaGuildsGuildChanges(c)
+		return w
+	}
+
+	w := request("/v4/guild/Elysium/changes")
+	assert.Equal(http.StatusOK, w.Code)
+
+	var response GuildChangesResponse
+	assert.Nil(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(response.GuildChanges.Changes, 1)
+	assert.Equal(GuildChangeJoined, response.GuildChanges.Changes[0].Type)
+	assert.Equal("Bobeek", response.GuildChanges.Changes[0].Character)
+
+	// since must not be in the future
+	w = request("/v4/guild/Elysium/changes?since=" + now.AddDate(0, 0, 1).Format(time.DateOnly))
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Contains(w.Body.String(), `"error":9004`)
+
+	w = request("/v4/guild/Elysium/changes?since=yesterday")
+	assert.Equal(http.StatusBadRequest, w.Code)
+
+	// the history must be enabled
+	TibiaDataHistory = nil
+	w = request("/v4/guild/Elysium/changes")
+	assert.Equal(http.StatusNotFound, w.Code)
+	assert.Contains(w.Body.String(), `"error":9003`)
+}
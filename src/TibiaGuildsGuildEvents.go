@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+// GuildEventType is the kind of an entry in the guild event log
+type GuildEventType string
+
+const (
+	GuildEventJoined            GuildEventType = "joined"
+	GuildEventLeft              GuildEventType = "left"
+	GuildEventKicked            GuildEventType = "kicked"
+	GuildEventInvited           GuildEventType = "invited"
+	GuildEventRankChanged       GuildEventType = "rank_changed"
+	GuildEventGuildhallAcquired GuildEventType = "guildhall_acquired"
+	GuildEventGuildhallLost     GuildEventType = "guildhall_lost"
+	GuildEventOther             GuildEventType = "other"
+)
+
+// Child of GuildEvents
+type GuildEvent struct {
+	Date      string         `json:"date"`                // The timestamp when the event occurred.
+	Type      GuildEventType `json:"type"`                // The type of the event.
+	Character string         `json:"character,omitempty"` // The character the event is about.
+	Actor     string         `json:"actor,omitempty"`     // The character that caused the event.
+	Rank      string         `json:"rank,omitempty"`      // The rank that was assigned. (when type: rank_changed)
+	Guildhall string         `json:"guildhall,omitempty"` // The name of the guildhall. (when type: guildhall_acquired or guildhall_lost)
+	Message   string         `json:"message"`             // The plain text of the event.
+}
+
+// Child of JSONData
+type GuildEvents struct {
+	Name   string       `json:"name"`   // The name of the guild.
+	Events []GuildEvent `json:"events"` // List of all events in the guild's event log.
+}
+
+// The base includes two levels: GuildEvents and Information
+type GuildEventsResponse struct {
+	GuildEvents GuildEvents `json:"guild_events"`
+	Information Information `json:"information"`
+}
+
+var (
+	GuildEventJoinedRegex            = regexp.MustCompile(`^(.*) joined the guild$`)
+	GuildEventLeftRegex              = regexp.MustCompile(`^(.*) left the guild$`)
+	GuildEventKickedRegex            = regexp.MustCompile(`^(.*) was kicked by (.*)$`)
+	GuildEventInvitedRegex           = regexp.MustCompile(`^(.*) invited (.*) to the guild$`)
+	GuildEventRankChangedRegex       = regexp.MustCompile(`^(.*) assigned the rank (.*) to (.*)$`)
+	GuildEventGuildhallAcquiredRegex = regexp.MustCompile(`^The guild acquired the guildhall (.*)$`)
+	GuildEventGuildhallLostRegex     = regexp.MustCompile(`^The guild lost the guildhall (.*)$`)
+)
+
+func TibiaGuildsGuildEventsImpl(guild string, BoxContentHTML string, url string) (GuildEventsResponse, error) {
+	// Creating empty vars
+	var GuildEventsData []GuildEvent
+
+	// Loading HTML data into ReaderHTML for goquery with NewReader
+	ReaderHTML, err := goquery.NewDocumentFromReader(strings.NewReader(BoxContentHTML))
+	if err != nil {
+		return GuildEventsResponse{}, fmt.Errorf("[error] TibiaGuildsGuildEventsImpl failed at goquery.NewDocumentFromReader, err: %s", err)
+	}
+
+	guildName, err := ReaderHTML.Find("h1").First().Html()
+	if err != nil {
+		return GuildEventsResponse{}, fmt.Errorf("[error] TibiaGuildsGuildEventsImpl failed at ReaderHTML.Find, err: %s", err)
+	}
+
+	if guildName == "" {
+		return GuildEventsResponse{}, validation.ErrorGuildNotFound
+	}
+
+	// Running query over each row of the event table
+	ReaderHTML.Find(".TableContentContainer .TableContent tr").Not(".LabelH").Each(func(index int, s *goquery.Selection) {
+		columns := s.Find("td")
+		if columns.Length() < 2 {
+			return
+		}
+
+		event := tibiaGuildsGuildEventsParseMessage(
+			strings.TrimSpace(TibiaDataSanitizeStrings(columns.Eq(1).Text())),
+		)
+		event.Date = TibiaDataDatetime(strings.TrimSpace(TibiaDataSanitizeStrings(columns.Eq(0).Text())))
+
+		GuildEventsData = append(GuildEventsData, event)
+	})
+
+	//
+	// Build the data-blob
+	return GuildEventsResponse{
+		GuildEvents{
+			Name:   guildName,
+			Events: GuildEventsData,
+		},
+		Information{
+			APIDetails: TibiaDataAPIDetails,
+			Timestamp:  TibiaDataDatetime(""),
+			TibiaURLs:  []string{url},
+			Status: Status{
+				HTTPCode: http.StatusOK,
+			},
+		},
+	}, nil
+}
+
+// tibiaGuildsGuildEventsParseMessage func - categorizes the plain text of one guild event
+func tibiaGuildsGuildEventsParseMessage(message string) GuildEvent {
+	event := GuildEvent{
+		Type:    GuildEventOther,
+		Message: message,
+	}
+
+	// character names can contain dots, so only the final one is removed
+	data := strings.TrimSuffix(message, ".")
+
+	if subma1 := GuildEventJoinedRegex.FindStringSubmatch(data); subma1 != nil {
+		event.Type = GuildEventJoined
+		event.Character = subma1[1]
+	} else if subma1 := GuildEventLeftRegex.FindStringSubmatch(data); subma1 != nil {
+		event.Type = GuildEventLeft
+		event.Character = subma1[1]
+	} else if subma1 := GuildEventKickedRegex.FindStringSubmatch(data); subma1 != nil {
+		event.Type = GuildEventKicked
+		event.Character = subma1[1]
+		event.Actor = subma1[2]
+	} else if subma1 := GuildEventInvitedRegex.FindStringSubmatch(data); subma1 != nil {
+		event.Type = GuildEventInvited
+		event.Character = subma1[2]
+		event.Actor = subma1[1]
+	} else if subma1 := GuildEventRankChangedRegex.FindStringSubmatch(data); subma1 != nil {
+		event.Type = GuildEventRankChanged
+		event.Character = subma1[3]
+		event.Actor = subma1[1]
+		event.Rank = subma1[2]
+	} else if subma1 := GuildEventGuildhallAcquiredRegex.FindStringSubmatch(data); subma1 != nil {
+		event.Type = GuildEventGuildhallAcquired
+		event.Guildhall = subma1[1]
+	} else if subma1 := GuildEventGuildhallLostRegex.FindStringSubmatch(data); subma1 != nil {
+		event.Type = GuildEventGuildhallLost
+		event.Guildhall = subma1[1]
+	}
+
+	return event
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"cmp"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/static"
+)
+
+func TestElysiumEvents(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/guilds/events/Elysium.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	elysiumJson, err := TibiaGuildsGuildEventsImpl("Elysium", string(data), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	events := elysiumJson.GuildEvents
+
+	assert.Equal("Elysium", events.Name)
+	assert.Equal(8, len(events.Events))
+
+	joined := events.Events[0]
+	assert.Equal("2024-03-16T12:27:03Z", joined.Date)
+	assert.Equal(GuildEventJoined, joined.Type)
+	assert.Equal("Lady Zyrelia", joined.Character)
+	assert.Empty(joined.Actor)
+	assert.Equal("Lady Zyrelia joined the guild.", joined.Message)
+
+	invited := events.Events[1]
+	assert.Equal(GuildEventInvited, invited.Type)
+	assert.Equal("Lady Zyrelia", invited.Character)
+	assert.Equal("Zyb the Warrior", invited.Actor)
+
+	kicked := events.Events[2]
+	assert.Equal(GuildEventKicked, kicked.Type)
+	assert.Equal("Dark Gadus", kicked.Character)
+	assert.Equal("Zyb the Warrior", kicked.Actor)
+
+	rankChanged := events.Events[3]
+	assert.Equal(GuildEventRankChanged, rankChanged.Type)
+	assert.Equal("Bubble Mage", rankChanged.Character)
+	assert.Equal("Zyb the Warrior", rankChanged.Actor)
+	assert.Equal("Vice Leader", rankChanged.Rank)
+
+	acquired := events.Events[4]
+	assert.Equal(GuildEventGuildhallAcquired, acquired.Type)
+	assert.Equal("Castle of the Winds", acquired.Guildhall)
+	assert.Empty(acquired.Character)
+
+	left := events.Events[5]
+	assert.Equal("2024-02-28T22:41:09Z", left.Date)
+	assert.Equal(GuildEventLeft, left.Type)
+	assert.Equal("Sir Lancelot", left.Character)
+
+	lost := events.Events[6]
+	assert.Equal(GuildEventGuildhallLost, lost.Type)
+	assert.Equal("Bloodhall", lost.Guildhall)
+
+	other := events.Events[7]
+	assert.Equal(GuildEventOther, other.Type)
+	assert.Equal("Zyb the Warrior changed the guild description.", other.Message)
+}
+
+func TestGuildEventsLive(t *testing.T) {
+	guild := cmp.Or(os.Getenv("TIBIADATA_LIVE_GUILD"), "Elysium")
+	url := "https://www.tibia.com/community/?subtopic=guilds&page=guildevents&action=view&GuildName=" + TibiaDataQueryEscapeString(guild)
+	data := tibiaDataLivePage(t, url, "guilds/events/"+guild+".html")
+
+	guildJson, err := TibiaGuildsGuildEventsImpl(guild, data, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	events := guildJson.GuildEvents
+
+	assert.Equal(guild, events.Name)
+
+	// the sentences of tibia.com are matched, so not every event is of an unknown type
+	var others int
+	for _, event := range events.Events {
+		assert.NotEmpty(event.Date, event.Message)
+		assert.NotEmpty(event.Message)
+		if event.Type == GuildEventOther {
+			others++
+			t.Logf("event of unknown type: %s", event.Message)
+		}
+	}
+	if len(events.Events) > 0 {
+		assert.Less(others, len(events.Events))
+	}
+}
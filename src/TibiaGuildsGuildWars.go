@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+// Child of GuildWars
+type GuildWar struct {
+	GuildName     string `json:"guild_name"`       // The name of the guild.
+	GuildScore    int    `json:"guild_score"`      // The number of kills the guild has scored.
+	OpponentName  string `json:"opponent_name"`    // The name of the opposing guild.
+	OpponentScore int    `json:"opponent_score"`   // The number of kills the opposing guild has scored.
+	ScoreLimit    int    `json:"score_limit"`      // The number of kills needed to win the war.
+	Fee           int    `json:"fee"`              // The war fee agreed on by the guilds in gold coins.
+	StartDate     string `json:"start_date"`       // The day the war started.
+	EndDate       string `json:"end_date"`         // The day the war ended or will end at the latest.
+	Winner        string `json:"winner,omitempty"` // The name of the guild that won the war.
+	Surrender     bool   `json:"surrender"`        // Whether the war ended by one guild surrendering.
+}
+
+// Child of JSONData
+type GuildWars struct {
+	Name    string     `json:"name"`    // The name of the guild.
+	InWar   bool       `json:"in_war"`  // Whether it is currently in war or not.
+	Current []GuildWar `json:"current"` // List of all wars the guild is currently in.
+	History []GuildWar `json:"history"` // List of all finished wars of the guild.
+}
+
+// The base includes two levels: GuildWars and Information
+type GuildWarsResponse struct {
+	GuildWars   GuildWars   `json:"guild_wars"`
+	Information Information `json:"information"`
+}
+
+var (
+	GuildWarsCurrentRegex    = regexp.MustCompile(`^The guild (.*) is at war with the guild (.*)$`)
+	GuildWarsHistoryRegex    = regexp.MustCompile(`^The guild (.*) fought against the guild (.*)$`)
+	GuildWarsDatesRegex      = regexp.MustCompile(`^The war started on ([A-Za-z]{3} [0-9]{2} [0-9]{4}) and (?:will end|ended) on ([A-Za-z]{3} [0-9]{2} [0-9]{4})`)
+	GuildWarsFeeRegex        = regexp.MustCompile(`^The guilds agreed on a war fee of ([0-9,]+) gold coins$`)
+	GuildWarsScoreLimitRegex = regexp.MustCompile(`^([0-9,]+) kills (?:are|were) needed to win the war$`)
+	GuildWarsScoreRegex      = regexp.MustCompile(`^(.*) has scored ([0-9,]+) kills? against (.*)$`)
+	GuildWarsWinnerRegex     = regexp.MustCompile(`^(.*) won the war$`)
+	GuildWarsSurrenderRegex  = regexp.MustCompile(`^(.*) surrendered$`)
+)
+
+func TibiaGuildsGuildWarsImpl(guild string, BoxContentHTML string, url string) (GuildWarsResponse, error) {
+	// Creating empty vars
+	var (
+		GuildWarsCurrent = []GuildWar{}
+		GuildWarsHistory = []GuildWar{}
+		GuildWarsInWar   bool
+	)
+
+	// Loading HTML data into ReaderHTML for goquery with NewReader
+	ReaderHTML, err := goquery.NewDocumentFromReader(strings.NewReader(BoxContentHTML))
+	if err != nil {
+		return GuildWarsResponse{}, fmt.Errorf("[error] TibiaGuildsGuildWarsImpl failed at goquery.NewDocumentFromReader, err: %s", err)
+	}
+
+	guildName, err := ReaderHTML.Find("h1").First().Html()
+	if err != nil {
+		return GuildWarsResponse{}, fmt.Errorf("[error] TibiaGuildsGuildWarsImpl failed at ReaderHTML.Find, err: %s", err)
+	}
+
+	if guildName == "" {
+		return GuildWarsResponse{}, validation.ErrorGuildNotFound
+	}
+
+	// Running query over each table container
+	ReaderHTML.Find(".TableContainer").Each(func(index int, s *goquery.Selection) {
+		SectionName := s.Find("div.Text").First().Text()
+
+		s.Find(".TableContent tr").Each(func(index int, s *goquery.Selection) {
+			war, ok := tibiaGuildsGuildWarsParseEntry(s.Text())
+			if !ok {
+				return
+			}
+
+			switch SectionName {
+			case "Current Wars":
+				GuildWarsInWar = true
+				GuildWarsCurrent = append(GuildWarsCurrent, war)
+			case "War History":
+				GuildWarsHistory = append(GuildWarsHistory, war)
+			}
+		})
+	})
+
+	//
+	// Build the data-blob
+	return GuildWarsResponse{
+		GuildWars{
+			Name:    guildName,
+			InWar:   GuildWarsInWar,
+			Current: GuildWarsCurrent,
+			History: GuildWarsHistory,
+		},
+		Information{
+			APIDetails: TibiaDataAPIDetails,
+			Timestamp:  TibiaDataDatetime(""),
+			TibiaURLs:  []string{url},
+			Status: Status{
+				HTTPCode: http.StatusOK,
+			},
+		},
+	}, nil
+}
+
+// tibiaGuildsGuildWarsParseEntry func - parses the text of one war and reports whether it was a war
+func tibiaGuildsGuildWarsParseEntry(data string) (GuildWar, bool) {
+	var (
+		war   GuildWar
+		isWar bool
+	)
+
+	// guild names can not contain dots, so it is safe to split the text into sentences
+	for sentence := range strings.SplitSeq(TibiaDataSanitizeStrings(data), ".") {
+		sentence = strings.TrimSpace(sentence)
+
+		if subma1 := GuildWarsCurrentRegex.FindStringSubmatch(sentence); subma1 != nil {
+			isWar = true
+			war.GuildName = subma1[1]
+			war.OpponentName = subma1[2]
+		} else if subma1 := GuildWarsHistoryRegex.FindStringSubmatch(sentence); subma1 != nil {
+			isWar = true
+			war.GuildName = subma1[1]
+			war.OpponentName = subma1[2]
+		} else if subma1 := GuildWarsDatesRegex.FindStringSubmatch(sentence); subma1 != nil {
+			war.StartDate = TibiaDataDate(subma1[1])
+			war.EndDate = TibiaDataDate(subma1[2])
+		} else if subma1 := GuildWarsFeeRegex.FindStringSubmatch(sentence); subma1 != nil {
+			war.Fee = TibiaDataStringToInteger(subma1[1])
+		} else if subma1 := GuildWarsScoreLimitRegex.FindStringSubmatch(sentence); subma1 != nil {
+			war.ScoreLimit = TibiaDataStringToInteger(subma1[1])
+		} else if subma1 := GuildWarsScoreRegex.FindStringSubmatch(sentence); subma1 != nil {
+			switch subma1[1] {
+			case war.GuildName:
+				war.GuildScore = TibiaDataStringToInteger(subma1[2])
+			case war.OpponentName:
+				war.OpponentScore = TibiaDataStringToInteger(subma1[2])
+			}
+		} else if subma1 := GuildWarsWinnerRegex.FindStringSubmatch(sentence); subma1 != nil {
+			war.Winner = subma1[1]
+		} else if subma1 := GuildWarsSurrenderRegex.FindStringSubmatch(sentence); subma1 != nil {
+			war.Surrender = true
+			switch subma1[1] {
+			case war.GuildName:
+				war.Winner = war.OpponentName
+			case war.OpponentName:
+				war.Winner = war.GuildName
+			}
+		}
+	}
+
+	return war, isWar
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"cmp"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/static"
+)
+
+func TestMercenarysWars(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/guilds/wars/Mercenarys.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	mercenarysJson, err := TibiaGuildsGuildWarsImpl("Mercenarys", string(data), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	wars := mercenarysJson.GuildWars
+
+	assert.Equal("Mercenarys", wars.Name)
+	assert.True(wars.InWar)
+
+	assert.Equal(1, len(wars.Current))
+	current := wars.Current[0]
+	assert.Equal("Mercenarys", current.GuildName)
+	assert.Equal(312, current.GuildScore)
+	assert.Equal("Rising Fenix", current.OpponentName)
+	assert.Equal(287, current.OpponentScore)
+	assert.Equal(1000, current.ScoreLimit)
+	assert.Equal(2500000, current.Fee)
+	assert.Equal("2023-09-03", current.StartDate)
+	assert.Equal("2024-03-01", current.EndDate)
+	assert.Empty(current.Winner)
+	assert.False(current.Surrender)
+
+	assert.Equal(3, len(wars.History))
+
+	won := wars.History[0]
+	assert.Equal("Mercenarys", won.GuildName)
+	assert.Equal(500, won.GuildScore)
+	assert.Equal("Old Kings", won.OpponentName)
+	assert.Equal(421, won.OpponentScore)
+	assert.Equal(500, won.ScoreLimit)
+	assert.Equal(1000000, won.Fee)
+	assert.Equal("2023-02-12", won.StartDate)
+	assert.Equal("2023-04-28", won.EndDate)
+	assert.Equal("Mercenarys", won.Winner)
+	assert.False(won.Surrender)
+
+	surrendered := wars.History[1]
+	assert.Equal("Bones Alliance", surrendered.OpponentName)
+	assert.Equal(97, surrendered.GuildScore)
+	assert.Equal(12, surrendered.OpponentScore)
+	assert.Equal(0, surrendered.Fee)
+	assert.Equal("Mercenarys", surrendered.Winner)
+	assert.True(surrendered.Surrender)
+
+	lost := wars.History[2]
+	assert.Equal("Red Rose", lost.OpponentName)
+	assert.Equal(100, lost.OpponentScore)
+	assert.Equal("Red Rose", lost.Winner)
+	assert.False(lost.Surrender)
+}
+
+func TestElysiumWars(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/guilds/wars/Elysium.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	elysiumJson, err := TibiaGuildsGuildWarsImpl("Elysium", string(data), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	wars := elysiumJson.GuildWars
+
+	assert.Equal("Elysium", wars.Name)
+	assert.False(wars.InWar)
+	assert.NotNil(wars.Current)
+	assert.Empty(wars.Current)
+	assert.NotNil(wars.History)
+	assert.Empty(wars.History)
+}
+
+func TestGuildWarsLive(t *testing.T) {
+	// guilds in war, with finished wars only and without any war, e.g. Mercenarys,Elysium
+	guilds := strings.Split(cmp.Or(os.Getenv("TIBIADATA_LIVE_GUILDS"), "Mercenarys,Elysium"), ",")
+
+	for _, guild := range guilds {
+		url := "https://www.tibia.com/community/?subtopic=guilds&page=guildwars&action=view&GuildName=" + TibiaDataQueryEscapeString(guild)
+		data := tibiaDataLivePage(t, url, "guilds/wars/"+guild+".html")
+
+		guildJson, err := TibiaGuildsGuildWarsImpl(guild, data, url)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert := assert.New(t)
+		wars := guildJson.GuildWars
+
+		assert.Equal(guild, wars.Name)
+		assert.Equal(len(wars.Current) > 0, wars.InWar)
+		for _, war := range append(wars.Current, wars.History...) {
+			assert.NotEmpty(war.GuildName, guild)
+			assert.NotEmpty(war.OpponentName, guild)
+			assert.NotEmpty(war.StartDate, guild)
+			assert.NotEmpty(war.EndDate, guild)
+		}
+	}
+}
@@ -14,13 +14,14 @@ import (
 
 // Child of Highscores
 type Highscore struct {
-	Rank     int    `json:"rank"`            // The character's rank/postition.
-	Name     string `json:"name"`            // The name of the character.
-	Vocation string `json:"vocation"`        // The character's vocation.
-	World    string `json:"world"`           // The character's world.
-	Level    int    `json:"level"`           // The character's level.
-	Value    int    `json:"value"`           // The character's value for the highscores or loyalty points.
-	Title    string `json:"title,omitempty"` // The character's loyalty title. (when category: loyalty)
+	Rank                  int    `json:"rank"`                               // The character's rank/postition.
+	Name                  string `json:"name"`                               // The name of the character.
+	Vocation              string `json:"vocation"`                           // The character's vocation.
+	World                 string `json:"world"`                              // The character's world.
+	Level                 int    `json:"level"`                              // The character's level.
+	Value                 int    `json:"value"`                              // The character's value for the highscores or loyalty points.
+	Title                 string `json:"title,omitempty"`                    // The character's loyalty title. (when category: loyalty)
+	ExperienceToNextLevel int    `json:"experience_to_next_level,omitempty"` // The experience points the character is missing for the next level. (when category: experience)
 }
 
 // Child of Highscore
@@ -139,7 +140,7 @@ func TibiaHighscoresImpl(world string, category validation.HighscoreCategory, vo
 				HighscoreDataValue = TibiaDataStringToInteger(subma1[0][6])
 			}
 
-			HighscoreData = append(HighscoreData, Highscore{
+			OneHighscore := Highscore{
 				Rank:     HighscoreDataRank,
 				Name:     TibiaDataSanitizeEscapedString(subma1[0][2]),
 				Vocation: HighscoreDataVocation,
@@ -147,7 +148,14 @@ func TibiaHighscoresImpl(world string, category validation.HighscoreCategory, vo
 				Level:    HighscoreDataLevel,
 				Value:    HighscoreDataValue,
 				Title:    HighscoreDataTitle,
-			})
+			}
+
+			// the value of the experience category are the character's experience points
+			if category == validation.HighScoreExperience {
+				OneHighscore.ExperienceToNextLevel = TibiaLibraryExperienceToNextLevel(HighscoreDataLevel, HighscoreDataValue)
+			}
+
+			HighscoreData = append(HighscoreData, OneHighscore)
 		}
 
 		return true
@@ -51,6 +51,7 @@ func TestHighscoresAll(t *testing.T) {
 	assert.Equal(2197, firstHighscore.Level)
 	assert.Equal(176271164607, firstHighscore.Value)
 	assert.Empty(firstHighscore.Title)
+	assert.Equal(229444093, firstHighscore.ExperienceToNextLevel)
 
 	lastHighscore := highscoresJson.Highscores.HighscoreList[49]
 	assert.Equal(50, lastHighscore.Rank)
@@ -60,6 +61,7 @@ func TestHighscoresAll(t *testing.T) {
 	assert.Equal(1701, lastHighscore.Level)
 	assert.Equal(81816135617, lastHighscore.Value)
 	assert.Empty(lastHighscore.Title)
+	assert.Equal(67339483, lastHighscore.ExperienceToNextLevel)
 }
 
 func TestHighscoresLoyalty(t *testing.T) {
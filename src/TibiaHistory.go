@@ -0,0 +1,52 @@
+package main
+
+import (
+	"net/http"
+	"time"
+)
+
+// Child of JSONData
+type History struct {
+	Type      string              `json:"type"`      // The kind of data (guild, world, highscores or killstatistics).
+	Name      string              `json:"name"`      // The name the snapshots belong to.
+	From      string              `json:"from"`      // The start of the time range.
+	To        string              `json:"to"`        // The end of the time range.
+	Snapshots []TibiaDataSnapshot `json:"snapshots"` // List of snapshots, oldest first.
+}
+
+// The base includes two levels: History and Information
+type HistoryResponse struct {
+	History     History     `json:"history"`
+	Information Information `json:"information"`
+}
+
+func TibiaHistoryImpl(kind, name string, from, to time.Time, limit int) (HistoryResponse, error) {
+	snapshots, err := TibiaDataHistory.Query(kind, name, from, to, limit)
+	if err != nil {
+		return HistoryResponse{}, err
+	}
+
+	history := History{
+		Type:      kind,
+		Name:      name,
+		To:        to.UTC().Format(time.RFC3339),
+		Snapshots: snapshots,
+	}
+	if !from.IsZero() {
+		history.From = from.UTC().Format(time.RFC3339)
+	}
+
+	//
+	// Build the data-blob
+	return HistoryResponse{
+		history,
+		Information{
+			APIDetails: TibiaDataAPIDetails,
+			Timestamp:  TibiaDataDatetime(""),
+			TibiaURLs:  []string{},
+			Status: Status{
+				HTTPCode: http.StatusOK,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Child of Leaderboard
+type LeaderboardEntry struct {
+	Rank     int    `json:"rank"`     // The character's rank/postition.
+	Name     string `json:"name"`     // The name of the character.
+	Vocation string `json:"vocation"` // The character's vocation.
+	Score    int    `json:"score"`    // The character's score in the rotation.
+}
+
+// Child of Leaderboard
+type LeaderboardRotation struct {
+	RotationID int    `json:"rotation_id"` // The id of the rotation.
+	Current    bool   `json:"current"`     // Whether the rotation is still running.
+	Start      string `json:"start"`       // The timestamp when the rotation started.
+	End        string `json:"end"`         // The timestamp when the rotation ends.
+}
+
+// Child of JSONData
+type Leaderboard struct {
+	World           string              `json:"world"`            // The world the leaderboard belongs to.
+	Rotation        LeaderboardRotation `json:"rotation"`         // The rotation being displayed.
+	LeaderboardAge  int                 `json:"leaderboard_age"`  // The age of the leaderboard in minutes.
+	LeaderboardList []LeaderboardEntry  `json:"leaderboard_list"` // List of leaderboard records.
+	LeaderboardPage HighscorePage       `json:"leaderboard_page"` // Information of leaderboard pages.
+}
+
+// The base includes two levels: Leaderboard and Information
+type LeaderboardResponse struct {
+	Leaderboard Leaderboard `json:"leaderboard"`
+	Information Information `json:"information"`
+}
+
+var (
+	LeaderboardRotationRegex = regexp.MustCompile(`Rotation ([0-9]+)( \(current\))?: (.*) - (.*)`)
+	LeaderboardAgeRegex      = regexp.MustCompile(`Last Update: ([0-9]+) minutes? ago`)
+)
+
+func TibiaLeaderboardsImpl(world string, BoxContentHTML string, url string) (LeaderboardResponse, error) {
+	// Creating empty vars
+	var (
+		LeaderboardData         []LeaderboardEntry
+		LeaderboardRotationData LeaderboardRotation
+		LeaderboardAge          int
+	)
+
+	// Loading HTML data into ReaderHTML for goquery with NewReader
+	ReaderHTML, err := goquery.NewDocumentFromReader(strings.NewReader(BoxContentHTML))
+	if err != nil {
+		return LeaderboardResponse{}, fmt.Errorf("[error] TibiaLeaderboardsImpl failed at goquery.NewDocumentFromReader, err: %s", err)
+	}
+
+	// getting the rotation being displayed
+	subma1 := LeaderboardRotationRegex.FindStringSubmatch(TibiaDataSanitizeStrings(ReaderHTML.Find(".LeaderboardRotation").First().Text()))
+	if len(subma1) > 0 {
+		LeaderboardRotationData = LeaderboardRotation{
+			RotationID: TibiaDataStringToInteger(subma1[1]),
+			Current:    subma1[2] != "",
+			Start:      TibiaDataDatetime(strings.TrimSpace(subma1[3])),
+			End:        TibiaDataDatetime(strings.TrimSpace(subma1[4])),
+		}
+	}
+
+	// getting age of data
+	subma1 = LeaderboardAgeRegex.FindStringSubmatch(ReaderHTML.Text())
+	if len(subma1) > 0 {
+		LeaderboardAge = TibiaDataStringToInteger(subma1[1])
+	}
+
+	// getting the pages
+	CurrentPage, TotalPages, TotalResults := TibiaDataPageNavigation(ReaderHTML.Selection)
+
+	// Running query over each row of the leaderboard
+	ReaderHTML.Find(".TableContent tr").Each(func(index int, s *goquery.Selection) {
+		columns := s.Find("td")
+		if columns.Length() != 4 || s.Find("a").Length() == 0 {
+			// the table headline
+			return
+		}
+
+		LeaderboardData = append(LeaderboardData, LeaderboardEntry{
+			Rank:     TibiaDataStringToInteger(strings.TrimSuffix(strings.TrimSpace(columns.Eq(0).Text()), ".")),
+			Name:     strings.TrimSpace(TibiaDataSanitizeStrings(columns.Eq(1).Text())),
+			Vocation: strings.TrimSpace(TibiaDataSanitizeStrings(columns.Eq(2).Text())),
+			Score:    TibiaDataStringToInteger(strings.TrimSpace(columns.Eq(3).Text())),
+		})
+	})
+
+	//
+	// Build the data-blob
+	return LeaderboardResponse{
+		Leaderboard{
+			World:           world,
+			Rotation:        LeaderboardRotationData,
+			LeaderboardAge:  LeaderboardAge,
+			LeaderboardList: LeaderboardData,
+			LeaderboardPage: HighscorePage{
+				CurrentPage:     CurrentPage,
+				TotalPages:      TotalPages,
+				TotalHighscores: TotalResults,
+			},
+		},
+		Information{
+			APIDetails: TibiaDataAPIDetails,
+			Timestamp:  TibiaDataDatetime(""),
+			TibiaURLs:  []string{url},
+			Status: Status{
+				HTTPCode: http.StatusOK,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/static"
+)
+
+func TestLeaderboardsAntica(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/leaderboards/Antica.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	leaderboardsJson, err := TibiaLeaderboardsImpl("Antica", string(data), "https://www.tibia.com/community/?subtopic=leaderboards&world=Antica&rotation=32&currentpage=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	leaderboard := leaderboardsJson.Leaderboard
+	information := leaderboardsJson.Information
+
+	assert.Equal("https://www.tibia.com/community/?subtopic=leaderboards&world=Antica&rotation=32&currentpage=1", information.TibiaURLs[0])
+
+	assert.Equal("Antica", leaderboard.World)
+	assert.Equal(7, leaderboard.LeaderboardAge)
+
+	assert.Equal(32, leaderboard.Rotation.RotationID)
+	assert.False(leaderboard.Rotation.Current)
+	assert.Equal("2024-03-13T09:00:00Z", leaderboard.Rotation.Start)
+	assert.Equal("2024-03-27T09:00:00Z", leaderboard.Rotation.End)
+
+	assert.Equal(1, leaderboard.LeaderboardPage.CurrentPage)
+	assert.Equal(20, leaderboard.LeaderboardPage.TotalPages)
+	assert.Equal(1000, leaderboard.LeaderboardPage.TotalHighscores)
+
+	assert.Equal(5, len(leaderboard.LeaderboardList))
+
+	first := leaderboard.LeaderboardList[0]
+	assert.Equal(1, first.Rank)
+	assert.Equal("Khaelis Thunderfist", first.Name)
+	assert.Equal("Elite Knight", first.Vocation)
+	assert.Equal(4512, first.Score)
+
+	third := leaderboard.LeaderboardList[2]
+	assert.Equal(3, third.Rank)
+	assert.Equal("Arch'Mage Tolvu", third.Name)
+	assert.Equal("Master Sorcerer", third.Vocation)
+	assert.Equal(4120, third.Score)
+
+	last := leaderboard.LeaderboardList[4]
+	assert.Equal(5, last.Rank)
+	assert.Equal("Brother Ishan", last.Name)
+	assert.Equal("Exalted Monk", last.Vocation)
+	assert.Equal(3874, last.Score)
+}
+
+func TestLeaderboardsLive(t *testing.T) {
+	url := "https://www.tibia.com/community/?subtopic=leaderboards&world=Antica"
+	data := tibiaDataLivePage(t, url, "leaderboards/Antica.html")
+
+	leaderboardJson, err := TibiaLeaderboardsImpl("Antica", data, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	leaderboard := leaderboardJson.Leaderboard
+
+	assert.Equal("Antica", leaderboard.World)
+	assert.Positive(leaderboard.Rotation.RotationID)
+	assert.True(leaderboard.Rotation.Current)
+	assert.NotEmpty(leaderboard.Rotation.Start)
+	assert.NotEmpty(leaderboard.Rotation.End)
+	assert.Equal(1, leaderboard.LeaderboardPage.CurrentPage)
+	assert.NotEmpty(leaderboard.LeaderboardList)
+
+	// the entries are sorted by their rank and score
+	for i, entry := range leaderboard.LeaderboardList {
+		assert.NotEmpty(entry.Name, entry.Rank)
+		assert.Positive(entry.Rank, entry.Name)
+		if i > 0 {
+			previous := leaderboard.LeaderboardList[i-1]
+			assert.GreaterOrEqual(entry.Rank, previous.Rank, entry.Name)
+			assert.LessOrEqual(entry.Score, previous.Score, entry.Name)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"net/http"
+)
+
+// Child of ExperienceTable
+type ExperienceLevel struct {
+	Level                 int `json:"level"`                    // The level.
+	Experience            int `json:"experience"`               // The experience points needed to reach the level.
+	ExperienceToNextLevel int `json:"experience_to_next_level"` // The experience points needed to advance from the level to the next one.
+}
+
+// Child of JSONData
+type ExperienceTable struct {
+	Levels []ExperienceLevel `json:"levels"` // List of levels with their experience points.
+}
+
+// Child of JSONData
+type ExperienceRange struct {
+	FromLevel  int `json:"from_level"` // The level to start from.
+	ToLevel    int `json:"to_level"`   // The level to reach.
+	Experience int `json:"experience"` // The experience points needed to advance from one level to the other.
+}
+
+// The base includes two levels: ExperienceTable and Information
+type ExperienceTableResponse struct {
+	ExperienceTable ExperienceTable `json:"experience_table"`
+	Information     Information     `json:"information"`
+}
+
+// The base includes two levels: ExperienceRange and Information
+type ExperienceRangeResponse struct {
+	ExperienceRange ExperienceRange `json:"experience_range"`
+	Information     Information     `json:"information"`
+}
+
+const (
+	// ExperienceTableMaxLevel is the highest level listed in the experience table
+	ExperienceTableMaxLevel = 3000
+
+	// ExperienceMaxLevel is the highest level experience points are calculated for
+	ExperienceMaxLevel = 100000
+)
+
+// the experience table is not scraped, it follows the formula tibia.com uses
+const experienceTableURL = "https://www.tibia.com/library/?subtopic=experiencetable"
+
+// TibiaLibraryExperienceForLevel func - returns the experience points needed to reach a level
+func TibiaLibraryExperienceForLevel(level int) int {
+	if level <= 1 {
+		return 0
+	}
+
+	// 50/3 * (level^3 - 6*level^2 + 17*level - 12), which is always a whole number
+	return 50 * (level*level*level - 6*level*level + 17*level - 12) / 3
+}
+
+// TibiaLibraryExperienceBetweenLevels func - returns the experience points needed to advance from one level to another
+func TibiaLibraryExperienceBetweenLevels(from int, to int) int {
+	return TibiaLibraryExperienceForLevel(to) - TibiaLibraryExperienceForLevel(from)
+}
+
+// TibiaLibraryExperienceToNextLevel func - returns the experience points a character with the given level and experience is missing for the next level
+func TibiaLibraryExperienceToNextLevel(level int, experience int) int {
+	missing := TibiaLibraryExperienceForLevel(level+1) - experience
+	if missing < 0 {
+		return 0
+	}
+
+	return missing
+}
+
+func TibiaLibraryExperienceTableImpl() ExperienceTableResponse {
+	// Creating empty vars
+	ExperienceLevels := make([]ExperienceLevel, 0, ExperienceTableMaxLevel)
+
+	for level := 1; level <= ExperienceTableMaxLevel; level++ {
+		ExperienceLevels = append(ExperienceLevels, ExperienceLevel{
+			Level:                 level,
+			Experience:            TibiaLibraryExperienceForLevel(level),
+			ExperienceToNextLevel: TibiaLibraryExperienceBetweenLevels(level, level+1),
+		})
+	}
+
+	//
+	// Build the data-blob
+	return ExperienceTableResponse{
+		ExperienceTable{
+			Levels: ExperienceLevels,
+		},
+		Information{
+			APIDetails: TibiaDataAPIDetails,
+			Timestamp:  TibiaDataDatetime(""),
+			TibiaURLs:  []string{experienceTableURL},
+			Status: Status{
+				HTTPCode: http.StatusOK,
+			},
+		},
+	}
+}
+
+func TibiaLibraryExperienceRangeImpl(from int, to int) ExperienceRangeResponse {
+	//
+	// Build the data-blob
+	return ExperienceRangeResponse{
+		ExperienceRange{
+			FromLevel:  from,
+			ToLevel:    to,
+			Experience: TibiaLibraryExperienceBetweenLevels(from, to),
+		},
+		Information{
+			APIDetails: TibiaDataAPIDetails,
+			Timestamp:  TibiaDataDatetime(""),
+			TibiaURLs:  []string{experienceTableURL},
+			Status: Status{
+				HTTPCode: http.StatusOK,
+			},
+		},
+	}
+}
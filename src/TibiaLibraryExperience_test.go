@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExperienceForLevel(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, TibiaLibraryExperienceForLevel(0))
+	assert.Equal(0, TibiaLibraryExperienceForLevel(1))
+	assert.Equal(100, TibiaLibraryExperienceForLevel(2))
+	assert.Equal(200, TibiaLibraryExperienceForLevel(3))
+	assert.Equal(400, TibiaLibraryExperienceForLevel(4))
+	assert.Equal(15694800, TibiaLibraryExperienceForLevel(100))
+	assert.Equal(449100849800, TibiaLibraryExperienceForLevel(3000))
+
+	assert.Equal(113695000, TibiaLibraryExperienceBetweenLevels(100, 200))
+	assert.Equal(0, TibiaLibraryExperienceBetweenLevels(8, 8))
+
+	assert.Equal(100, TibiaLibraryExperienceToNextLevel(1, 0))
+	assert.Equal(50, TibiaLibraryExperienceToNextLevel(1, 50))
+	assert.Equal(0, TibiaLibraryExperienceToNextLevel(1, 150))
+}
+
+func TestExperienceTable(t *testing.T) {
+	experienceJson := TibiaLibraryExperienceTableImpl()
+
+	assert := assert.New(t)
+	levels := experienceJson.ExperienceTable.Levels
+
+	assert.Equal(ExperienceTableMaxLevel, len(levels))
+
+	assert.Equal(1, levels[0].Level)
+	assert.Equal(0, levels[0].Experience)
+	assert.Equal(100, levels[0].ExperienceToNextLevel)
+
+	assert.Equal(100, levels[99].Level)
+	assert.Equal(15694800, levels[99].Experience)
+	assert.Equal(TibiaLibraryExperienceForLevel(101)-15694800, levels[99].ExperienceToNextLevel)
+
+	assert.Equal(3000, levels[2999].Level)
+	assert.Equal(449100849800, levels[2999].Experience)
+}
+
+func TestExperienceRange(t *testing.T) {
+	experienceJson := TibiaLibraryExperienceRangeImpl(100, 200)
+
+	assert := assert.New(t)
+	experienceRange := experienceJson.ExperienceRange
+
+	assert.Equal(100, experienceRange.FromLevel)
+	assert.Equal(200, experienceRange.ToLevel)
+	assert.Equal(113695000, experienceRange.Experience)
+}
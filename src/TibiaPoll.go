@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+// Child of Poll
+type PollOption struct {
+	Option     string  `json:"option"`     // The answer that could be voted for.
+	Votes      int     `json:"votes"`      // The number of votes for the option.
+	Percentage float64 `json:"percentage"` // The share of all votes in percent.
+}
+
+// Child of JSONData
+type Poll struct {
+	ID          int          `json:"id"`          // The internal ID of the poll.
+	Title       string       `json:"title"`       // The topic of the poll.
+	Description string       `json:"description"` // The question of the poll in plain text.
+	StartDate   string       `json:"start_date"`  // The date when the poll started.
+	EndDate     string       `json:"end_date"`    // The date when the poll ends or ended.
+	Open        bool         `json:"open"`        // Whether the poll is still running.
+	TotalVotes  int          `json:"total_votes"` // The number of votes in total.
+	Options     []PollOption `json:"options"`     // List of options with their results.
+	TibiaURL    string       `json:"url"`         // The URL for the poll with id.
+}
+
+// The base
+type PollResponse struct {
+	Poll        Poll        `json:"poll"`
+	Information Information `json:"information"`
+}
+
+var (
+	PollRuntimeRegex = regexp.MustCompile(`Runtime: (.*) - (.*)`)
+	PollResultRegex  = regexp.MustCompile(`([0-9.]+)% \(([0-9,]+)\)`)
+	PollTotalRegex   = regexp.MustCompile(`Total Votes: ([0-9,]+)`)
+)
+
+func TibiaPollImpl(PollID int, rawUrl string, BoxContentHTML string) (PollResponse, error) {
+	// Loading HTML data into ReaderHTML for goquery with NewReader
+	ReaderHTML, err := goquery.NewDocumentFromReader(strings.NewReader(BoxContentHTML))
+	if err != nil {
+		return PollResponse{}, fmt.Errorf("[error] TibiaPollImpl failed at goquery.NewDocumentFromReader, err: %s", err)
+	}
+
+	// tibia.com shows an error box if the poll does not exist
+	PollTopic := ReaderHTML.Find(".PollTopic")
+	if PollTopic.Length() == 0 {
+		return PollResponse{}, validation.ErrorPollNotFound
+	}
+
+	// keeping the line breaks of the description
+	PollDescription, err := ReaderHTML.Find(".PollDescription").Html()
+	if err != nil {
+		return PollResponse{}, fmt.Errorf("[error] TibiaPollImpl failed at ReaderHTML.Find(\".PollDescription\").Html(), err: %s", err)
+	}
+
+	PollData := Poll{
+		ID:          PollID,
+		Title:       strings.TrimSpace(TibiaDataSanitizeStrings(PollTopic.Text())),
+		Description: strings.TrimSpace(TibiaDataSanitizeStrings(RemoveHtmlTag(strings.ReplaceAll(PollDescription, "<br/>", "\n")))),
+		Open:        strings.Contains(ReaderHTML.Find(".PollStatus").Text(), "running"),
+		TibiaURL:    rawUrl,
+	}
+
+	// getting the runtime of the poll
+	subma1 := PollRuntimeRegex.FindStringSubmatch(TibiaDataSanitizeStrings(ReaderHTML.Find(".PollRuntime").Text()))
+	if len(subma1) > 0 {
+		PollData.StartDate = TibiaDataDate(subma1[1])
+		PollData.EndDate = TibiaDataDate(subma1[2])
+	}
+
+	subma1 = PollTotalRegex.FindStringSubmatch(ReaderHTML.Find(".PollTotal").Text())
+	if len(subma1) > 0 {
+		PollData.TotalVotes = TibiaDataStringToInteger(subma1[1])
+	}
+
+	// Running query over each option of the poll
+	ReaderHTML.Find(".TableContent tr").Not(".LabelH").Each(func(index int, s *goquery.Selection) {
+		columns := s.Find("td")
+		subma1 := PollResultRegex.FindStringSubmatch(columns.Last().Text())
+		if len(subma1) == 0 {
+			return
+		}
+
+		Percentage, _ := strconv.ParseFloat(subma1[1], 64)
+		PollData.Options = append(PollData.Options, PollOption{
+			Option:     strings.TrimSpace(TibiaDataSanitizeStrings(columns.First().Text())),
+			Votes:      TibiaDataStringToInteger(subma1[2]),
+			Percentage: Percentage,
+		})
+	})
+
+	//
+	// Build the data-blob
+	return PollResponse{
+		PollData,
+		Information{
+			APIDetails: TibiaDataAPIDetails,
+			Timestamp:  TibiaDataDatetime(""),
+			TibiaURLs:  []string{rawUrl},
+			Status: Status{
+				HTTPCode: http.StatusOK,
+			},
+		},
+	}, nil
+}
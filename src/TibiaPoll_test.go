@@ -0,0 +1,113 @@
+package main
+
+import (
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/static"
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+func TestPollById(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/polls/poll.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	pollJson, err := TibiaPollImpl(3412, "https://www.tibia.com/community/?subtopic=polls&id=3412", string(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	poll := pollJson.Poll
+	information := pollJson.Information
+
+	assert.Equal("https://www.tibia.com/community/?subtopic=polls&id=3412", information.TibiaURLs[0])
+
+	assert.Equal(3412, poll.ID)
+	assert.Equal("Preferred Summer Update Feature", poll.Title)
+	assert.Equal("Dear Tibians,\nwhich of the following features would you like to see most in the summer update?", poll.Description)
+	assert.Equal("2024-03-18", poll.StartDate)
+	assert.Equal("2024-04-01", poll.EndDate)
+	assert.False(poll.Open)
+	assert.Equal(3646, poll.TotalVotes)
+	assert.Equal("https://www.tibia.com/community/?subtopic=polls&id=3412", poll.TibiaURL)
+
+	assert.Equal(3, len(poll.Options))
+
+	firstOption := poll.Options[0]
+	assert.Equal("New wave of Tibiadrome rewards", firstOption.Option)
+	assert.Equal(1645, firstOption.Votes)
+	assert.Equal(45.12, firstOption.Percentage)
+
+	lastOption := poll.Options[2]
+	assert.Equal("More house decoration items", lastOption.Option)
+	assert.Equal(899, lastOption.Votes)
+	assert.Equal(24.66, lastOption.Percentage)
+}
+
+func TestPollNotFound(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/polls/poll_notfound.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	_, err = TibiaPollImpl(99999, "https://www.tibia.com/community/?subtopic=polls&id=99999", string(data))
+	assert.ErrorIs(t, err, validation.ErrorPollNotFound)
+}
+
+func TestPollLive(t *testing.T) {
+	assert := assert.New(t)
+
+	// the first poll of the list
+	url := "https://www.tibia.com/community/?subtopic=polls"
+	pollsJson, err := TibiaPollslistImpl(tibiaDataLivePage(t, url, ""), url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !assert.NotEmpty(pollsJson.Polls) {
+		t.FailNow()
+	}
+
+	pollID := pollsJson.Polls[0].ID
+	url = "https://www.tibia.com/community/?subtopic=polls&id=" + strconv.Itoa(pollID)
+	pollJson, err := TibiaPollImpl(pollID, url, tibiaDataLivePage(t, url, "polls/poll.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	poll := pollJson.Poll
+	assert.Equal(pollID, poll.ID)
+	assert.Equal(pollsJson.Polls[0].Title, poll.Title)
+	assert.NotEmpty(poll.Description)
+	assert.NotEmpty(poll.StartDate)
+	assert.NotEmpty(poll.EndDate)
+	assert.NotEmpty(poll.Options)
+
+	// the votes of the options add up to the votes in total
+	var votes int
+	for _, option := range poll.Options {
+		assert.NotEmpty(option.Option)
+		votes += option.Votes
+	}
+	assert.Equal(poll.TotalVotes, votes)
+
+	url = "https://www.tibia.com/community/?subtopic=polls&id=99999"
+	_, err = TibiaPollImpl(99999, url, tibiaDataLivePage(t, url, "polls/poll_notfound.html"))
+	assert.ErrorIs(err, validation.ErrorPollNotFound)
+}
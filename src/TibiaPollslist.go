@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Child of JSONData
+type PollItem struct {
+	ID       int    `json:"id"`                // The internal ID of the poll.
+	Title    string `json:"title"`             // The topic of the poll.
+	EndDate  string `json:"end_date"`          // The date when the poll ends or ended.
+	Open     bool   `json:"open"`              // Whether the poll is still running.
+	TibiaURL string `json:"url"`               // The URL for the poll with id.
+	ApiURL   string `json:"url_api,omitempty"` // The URL for the poll in this API.
+}
+
+// The base
+type PollsListResponse struct {
+	Polls       []PollItem  `json:"polls"`
+	Information Information `json:"information"`
+}
+
+func TibiaPollslistImpl(BoxContentHTML string, handlerURL string) (PollsListResponse, error) {
+	// Declaring vars for later use..
+	var PollsListData []PollItem
+
+	// Loading HTML data into ReaderHTML for goquery with NewReader
+	ReaderHTML, err := goquery.NewDocumentFromReader(strings.NewReader(BoxContentHTML))
+	if err != nil {
+		return PollsListResponse{}, fmt.Errorf("[error] TibiaPollslistImpl failed at goquery.NewDocumentFromReader, err: %s", err)
+	}
+
+	var insideError error
+
+	ReaderHTML.Find(".TableContainer").EachWithBreak(func(index int, s *goquery.Selection) bool {
+		// current polls are listed in their own table
+		Open := strings.Contains(s.Find(".Text").First().Text(), "Current Polls")
+
+		s.Find(".Odd,.Even").EachWithBreak(func(index int, s *goquery.Selection) bool {
+			PollLink := s.Find("a")
+			if PollLink.Length() == 0 {
+				// no polls in this table
+				return true
+			}
+
+			// getting the id from the URL
+			PollURL, _ := PollLink.Attr("href")
+			p, err := url.Parse(PollURL)
+			if err != nil {
+				insideError = fmt.Errorf("[error] TibiaPollslistImpl failed at p, err := url.Parse(PollURL), err: %s", err)
+				return false
+			}
+			PollID := TibiaDataStringToInteger(p.Query().Get("id"))
+
+			OnePoll := PollItem{
+				ID:       PollID,
+				Title:    strings.TrimSpace(TibiaDataSanitizeStrings(PollLink.Text())),
+				EndDate:  TibiaDataDate(s.Find("td").Eq(1).Text()),
+				Open:     Open,
+				TibiaURL: "https://www.tibia.com/community/?subtopic=polls&id=" + strconv.Itoa(PollID),
+			}
+
+			if TibiaDataHost != "" {
+				OnePoll.ApiURL = TibiaDataProtocol + "://" + TibiaDataHost + "/v4/poll/" + strconv.Itoa(PollID)
+			}
+
+			// add to PollsListData for response
+			PollsListData = append(PollsListData, OnePoll)
+
+			return true
+		})
+
+		return insideError == nil
+	})
+
+	if insideError != nil {
+		return PollsListResponse{}, insideError
+	}
+
+	//
+	// Build the data-blob
+	return PollsListResponse{
+		PollsListData,
+		Information{
+			APIDetails: TibiaDataAPIDetails,
+			Timestamp:  TibiaDataDatetime(""),
+			TibiaURLs:  []string{handlerURL},
+			Status: Status{
+				HTTPCode: http.StatusOK,
+			},
+		},
+	}, nil
+}
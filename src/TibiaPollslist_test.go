@@ -0,0 +1,73 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/static"
+)
+
+func TestPollslist(t *testing.T) {
+	file, err := static.TestFiles.Open("testdata/polls/polls.html")
+	if err != nil {
+		t.Fatalf("file opening error: %s", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("File reading error: %s", err)
+	}
+
+	pollsJson, err := TibiaPollslistImpl(string(data), "https://www.tibia.com/community/?subtopic=polls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	information := pollsJson.Information
+
+	assert.Equal("https://www.tibia.com/community/?subtopic=polls", information.TibiaURLs[0])
+	assert.Equal(4, len(pollsJson.Polls))
+
+	currentPoll := pollsJson.Polls[0]
+	assert.Equal(3415, currentPoll.ID)
+	assert.Equal("Which region should receive the next hunting ground?", currentPoll.Title)
+	assert.Equal("2024-05-08", currentPoll.EndDate)
+	assert.True(currentPoll.Open)
+	assert.Equal("https://www.tibia.com/community/?subtopic=polls&id=3415", currentPoll.TibiaURL)
+
+	pastPoll := pollsJson.Polls[1]
+	assert.Equal(3412, pastPoll.ID)
+	assert.Equal("Preferred Summer Update Feature", pastPoll.Title)
+	assert.Equal("2024-04-01", pastPoll.EndDate)
+	assert.False(pastPoll.Open)
+	assert.Equal("https://www.tibia.com/community/?subtopic=polls&id=3412", pastPoll.TibiaURL)
+
+	lastPoll := pollsJson.Polls[3]
+	assert.Equal(3401, lastPoll.ID)
+	assert.Equal("Favourite Event of 2023", lastPoll.Title)
+	assert.Equal("2023-12-31", lastPoll.EndDate)
+	assert.False(lastPoll.Open)
+}
+
+func TestPollslistLive(t *testing.T) {
+	url := "https://www.tibia.com/community/?subtopic=polls"
+	data := tibiaDataLivePage(t, url, "polls/polls.html")
+
+	pollsJson, err := TibiaPollslistImpl(data, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+
+	assert.NotEmpty(pollsJson.Polls)
+	for _, poll := range pollsJson.Polls {
+		assert.Positive(poll.ID, poll.Title)
+		assert.NotEmpty(poll.Title)
+		assert.NotEmpty(poll.EndDate, poll.Title)
+		assert.NotEmpty(poll.TibiaURL, poll.Title)
+	}
+}
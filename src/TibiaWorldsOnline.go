@@ -0,0 +1,143 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+// Child of Online
+type OnlineCharacter struct {
+	Name     string `json:"name"`     // The name of the character.
+	Level    int    `json:"level"`    // The character's level.
+	Vocation string `json:"vocation"` // The character's vocation.
+	World    string `json:"world"`    // The world the character is online on.
+}
+
+// Child of Online
+type OnlineFilters struct {
+	Vocation  string `json:"vocation"`             // The vocation filtered on.
+	LevelFrom int    `json:"level_from,omitempty"` // The minimum level filtered on.
+	LevelTo   int    `json:"level_to,omitempty"`   // The maximum level filtered on.
+}
+
+// Child of JSONData
+type Online struct {
+	Filters       OnlineFilters     `json:"filters"`                 // The filters the online characters were searched with.
+	PlayersOnline int               `json:"players_online"`          // The number of online characters matching the filters.
+	OnlinePlayers []OnlineCharacter `json:"online_players"`          // List of online characters matching the filters.
+	FailedWorlds  []string          `json:"failed_worlds,omitempty"` // List of worlds that could not be fetched.
+}
+
+// The base includes two levels: Online and Information
+type OnlineResponse struct {
+	Online      Online      `json:"online"`
+	Information Information `json:"information"`
+}
+
+// TibiaWorldsOnlineImpl func - fetches all worlds with at most concurrency requests at a time and merges their online players
+func TibiaWorldsOnlineImpl(worlds []string, filters OnlineFilters, concurrency int, fetch func(world string) (WorldResponse, error)) (OnlineResponse, error) {
+	// Creating empty vars
+	var (
+		OnlineData   []OnlineCharacter
+		FailedWorlds []string
+		TibiaURLs    []string
+		firstErr     error
+
+		results = make([]WorldResponse, len(worlds))
+		errs    = make([]error, len(worlds))
+		limiter = make(chan struct{}, max(concurrency, 1))
+		wg      sync.WaitGroup
+	)
+
+	for i, world := range worlds {
+		wg.Add(1)
+		go func(i int, world string) {
+			defer wg.Done()
+
+			limiter <- struct{}{}
+			defer func() { <-limiter }()
+
+			results[i], errs[i] = fetch(world)
+		}(i, world)
+	}
+	wg.Wait()
+
+	// merging the worlds in the order they were requested
+	for i, world := range worlds {
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			FailedWorlds = append(FailedWorlds, world)
+			continue
+		}
+
+		TibiaURLs = append(TibiaURLs, results[i].Information.TibiaURLs...)
+
+		for _, player := range results[i].World.OnlinePlayers {
+			if !tibiaWorldsOnlineMatches(filters, player) {
+				continue
+			}
+
+			OnlineData = append(OnlineData, OnlineCharacter{
+				Name:     player.Name,
+				Level:    player.Level,
+				Vocation: player.Vocation,
+				World:    results[i].World.Name,
+			})
+		}
+	}
+
+	// nothing to return if not a single world could be fetched
+	if len(worlds) > 0 && len(FailedWorlds) == len(worlds) {
+		return OnlineResponse{}, firstErr
+	}
+
+	status := Status{
+		HTTPCode: http.StatusOK,
+	}
+	if len(FailedWorlds) > 0 {
+		status.Error = validation.ErrorWorldsPartiallyUnavailable.Code()
+		status.Message = validation.ErrorWorldsPartiallyUnavailable.Error() + ": " + strings.Join(FailedWorlds, ", ")
+	}
+
+	//
+	// Build the data-blob
+	return OnlineResponse{
+		Online{
+			Filters:       filters,
+			PlayersOnline: len(OnlineData),
+			OnlinePlayers: OnlineData,
+			FailedWorlds:  FailedWorlds,
+		},
+		Information{
+			APIDetails: TibiaDataAPIDetails,
+			Timestamp:  TibiaDataDatetime(""),
+			TibiaURLs:  TibiaURLs,
+			Status:     status,
+		},
+	}, nil
+}
+
+// tibiaWorldsOnlineMatches func - reports whether an online player matches the filters
+func tibiaWorldsOnlineMatches(filters OnlineFilters, player OnlinePlayers) bool {
+	if filters.LevelFrom > 0 && player.Level < filters.LevelFrom {
+		return false
+	}
+	if filters.LevelTo > 0 && player.Level > filters.LevelTo {
+		return false
+	}
+
+	switch filters.Vocation {
+	case "", "all":
+		return true
+	case "none":
+		return player.Vocation == "None"
+	default:
+		// promoted vocations contain the base vocation (e.g. Elite Knight)
+		return strings.Contains(strings.ToLower(player.Vocation), strings.TrimSuffix(filters.Vocation, "s"))
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tibiadata/tibiadata-api-go/src/static"
+	"github.com/tibiadata/tibiadata-api-go/src/validation"
+)
+
+// tibiaWorldsOnlineTestFetch func - reads the world from the testdata instead of tibia.com
+func tibiaWorldsOnlineTestFetch(world string) (WorldResponse, error) {
+	file, err := static.TestFiles.Open("testdata/worlds/world/" + world + ".html")
+	if err != nil {
+		return WorldResponse{}, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return WorldResponse{}, err
+	}
+
+	return TibiaWorldsWorldImpl(world, string(data), "https://www.tibia.com/community/?subtopic=worlds&world="+world)
+}
+
+func TestWorldsOnline(t *testing.T) {
+	onlineJson, err := TibiaWorldsOnlineImpl([]string{"Premia", "Zuna", "Testa"}, OnlineFilters{Vocation: "all"}, 2, tibiaWorldsOnlineTestFetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	online := onlineJson.Online
+
+	assert.Equal(53+15+87, online.PlayersOnline)
+	assert.Equal(53+15+87, len(online.OnlinePlayers))
+	assert.Nil(online.FailedWorlds)
+	assert.Equal(3, len(onlineJson.Information.TibiaURLs))
+	assert.Equal(200, onlineJson.Information.Status.HTTPCode)
+	assert.Equal(0, onlineJson.Information.Status.Error)
+
+	// worlds are merged in the order they were requested
+	assert.Equal("Premia", online.OnlinePlayers[0].World)
+	assert.Equal("Zuna", online.OnlinePlayers[53].World)
+	assert.Equal("Paladin", online.OnlinePlayers[53].Vocation)
+	assert.Equal("Testa", online.OnlinePlayers[53+15].World)
+	assert.Equal("Monk", online.OnlinePlayers[53+15].Vocation)
+}
+
+func TestWorldsOnlineFilters(t *testing.T) {
+	filters := OnlineFilters{Vocation: "monks", LevelFrom: 8, LevelTo: 200}
+	onlineJson, err := TibiaWorldsOnlineImpl([]string{"Premia", "Zuna", "Testa"}, filters, 3, tibiaWorldsOnlineTestFetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	online := onlineJson.Online
+
+	assert.Equal(filters, online.Filters)
+	assert.NotEmpty(online.OnlinePlayers)
+	for _, player := range online.OnlinePlayers {
+		assert.Contains(player.Vocation, "Monk")
+		assert.GreaterOrEqual(player.Level, 8)
+		assert.LessOrEqual(player.Level, 200)
+	}
+}
+
+func TestWorldsOnlinePartialFailure(t *testing.T) {
+	onlineJson, err := TibiaWorldsOnlineImpl([]string{"Zuna", "Nonexistia"}, OnlineFilters{Vocation: "all"}, 1, tibiaWorldsOnlineTestFetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+
+	assert.Equal(15, onlineJson.Online.PlayersOnline)
+	assert.Equal([]string{"Nonexistia"}, onlineJson.Online.FailedWorlds)
+	assert.Equal(200, onlineJson.Information.Status.HTTPCode)
+	assert.Equal(validation.ErrorWorldsPartiallyUnavailable.Code(), onlineJson.Information.Status.Error)
+	assert.Equal("could not fetch all worlds from tibia.com: Nonexistia", onlineJson.Information.Status.Message)
+}
+
+func TestWorldsOnlineAllFailed(t *testing.T) {
+	maintenance := func(world string) (WorldResponse, error) {
+		return WorldResponse{}, validation.ErrorMaintenanceMode
+	}
+
+	_, err := TibiaWorldsOnlineImpl([]string{"Antica", "Zuna"}, OnlineFilters{Vocation: "all"}, 2, maintenance)
+	assert.True(t, errors.Is(err, validation.ErrorMaintenanceMode))
+}
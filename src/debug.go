@@ -30,6 +30,11 @@ type Debug struct {
 	BiggestSpellNameOrFormulaRuneCount  int    `json:"biggest_spell_name_or_formula_rune_count"`
 	SmallestSpellWordRuneCount          int    `json:"smallest_spell_word_rune_count"`
 	BiggestSpellWordRuneCount           int    `json:"biggest_spell_word_rune_count"`
+
+	Coalescing     TibiaDataCoalescingStats     `json:"coalescing"`
+	RateLimiter    TibiaDataRateLimiterStats    `json:"rate_limiter"`
+	CircuitBreaker TibiaDataCircuitBreakerStats `json:"circuit_breaker"`
+	Proxies        TibiaDataProxyPoolStats      `json:"proxies"`
 }
 
 // TibiaDataRequestTraceLogger func - prints out trace information to log
@@ -65,6 +70,10 @@ func debugHandler(c *gin.Context) {
 
 	debug := Debug{
 		TibiaDataUserAgent: TibiaDataUserAgent,
+		Coalescing:         TibiaDataCoalescing.Stats(),
+		RateLimiter:        TibiaDataLimiter.Stats(),
+		CircuitBreaker:     TibiaDataBreaker.Stats(),
+		Proxies:            TibiaDataProxies.Stats(),
 	}
 
 	// Shas
@@ -2,7 +2,9 @@ package main
 
 import (
 	"log"
+	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/tibiadata/tibiadata-api-go/src/validation"
 )
@@ -24,6 +26,78 @@ var (
 	TibiaDataHost       string     // set through env TIBIADATA_HOST
 	TibiaDataProtocol   = "https"  // can be overridden by env TIBIADATA_PROTOCOL
 
+	// TibiaDataOnlineConcurrency - amount of worlds requested at the same time for the online endpoint
+	TibiaDataOnlineConcurrency = 10 // can be overridden by env TIBIADATA_ONLINE_CONCURRENCY
+
+	// TibiaDataCacheEnabled - whether responses of tibia.com are cached
+	TibiaDataCacheEnabled = true // can be overridden by env TIBIADATA_CACHE_ENABLED
+
+	// TibiaDataCacheDefaultTTL - time to live of cached responses for handlers without an own TTL
+	TibiaDataCacheDefaultTTL = time.Minute // can be overridden by env TIBIADATA_CACHE_DEFAULT_TTL
+
+	// TibiaDataCacheDefaultMaxStale - how long expired responses can be served while tibia.com is failing for handlers without an own max stale
+	TibiaDataCacheDefaultMaxStale = time.Hour // can be overridden by env TIBIADATA_CACHE_MAX_STALE
+
+	// TibiaDataCacheBackend - where responses of tibia.com are cached (memory, disk or redis)
+	TibiaDataCacheBackend = "memory" // can be overridden by env TIBIADATA_CACHE_BACKEND
+
+	// TibiaDataCacheMaxEntries - amount of responses kept by the memory backend
+	TibiaDataCacheMaxEntries = 10000 // can be overridden by env TIBIADATA_CACHE_MAX_ENTRIES
+
+	// TibiaDataRateLimit - requests per second sent to tibia.com (0 disables the rate limiter)
+	TibiaDataRateLimit = 0.0 // can be overridden by env TIBIADATA_RATE_LIMIT
+
+	// TibiaDataRateLimitBurst - requests sent to tibia.com at once after being idle
+	TibiaDataRateLimitBurst = 10 // can be overridden by env TIBIADATA_RATE_LIMIT_BURST
+
+	// TibiaDataRateLimitMaxWait - how long a request waits for the rate limiter before 503 is returned
+	TibiaDataRateLimitMaxWait = 10 * time.Second // can be overridden by env TIBIADATA_RATE_LIMIT_MAX_WAIT
+
+	// TibiaDataBreakerThreshold - consecutive failures of tibia.com until requests are paused
+	TibiaDataBreakerThreshold = 5 // can be overridden by env TIBIADATA_BREAKER_THRESHOLD
+
+	// TibiaDataBreakerCooldown - how long requests are paused the first time, doubling with every further pause
+	TibiaDataBreakerCooldown = 10 * time.Second // can be overridden by env TIBIADATA_BREAKER_COOLDOWN
+
+	// TibiaDataBreakerMaxCooldown - how long requests are paused at most
+	TibiaDataBreakerMaxCooldown = 5 * time.Minute // can be overridden by env TIBIADATA_BREAKER_MAX_COOLDOWN
+
+	// TibiaDataProxyStrategy - how the proxy of a request is selected (round-robin or least-throttled)
+	TibiaDataProxyStrategy = TibiaDataProxyRoundRobin // can be overridden by env TIBIADATA_PROXY_STRATEGY
+
+	// TibiaDataProxyEjectThreshold - consecutive failures of a proxy until it is ejected
+	TibiaDataProxyEjectThreshold = 3 // can be overridden by env TIBIADATA_PROXY_EJECT_THRESHOLD
+
+	// TibiaDataProxyEjectDuration - how long a proxy is ejected the first time, doubling with every further ejection
+	TibiaDataProxyEjectDuration = 30 * time.Second // can be overridden by env TIBIADATA_PROXY_EJECT_DURATION
+
+	// TibiaDataProxyMaxEjectDuration - how long a proxy is ejected at most
+	TibiaDataProxyMaxEjectDuration = 10 * time.Minute // can be overridden by env TIBIADATA_PROXY_MAX_EJECT_DURATION
+
+	// TibiaDataProxyProbeInterval - how often ejected proxies are probed (0 disables probing)
+	TibiaDataProxyProbeInterval = 10 * time.Second // can be overridden by env TIBIADATA_PROXY_PROBE_INTERVAL
+
+	// TibiaDataHistoryDir - where snapshots of guilds, worlds, highscores and killstatistics are recorded (empty disables the history)
+	TibiaDataHistoryDir = "" // can be overridden by env TIBIADATA_HISTORY_DIR
+
+	// TibiaDataHistoryInterval - how often a snapshot of the same data is recorded at most
+	TibiaDataHistoryInterval = time.Hour // can be overridden by env TIBIADATA_HISTORY_INTERVAL
+
+	// TibiaDataHistoryRetention - how long snapshots are kept (0 keeps them forever)
+	TibiaDataHistoryRetention = 90 * 24 * time.Hour // can be overridden by env TIBIADATA_HISTORY_RETENTION
+
+	// TibiaDataCrawlerConfig - the JSON file of the jobs of the crawler (takes precedence over TIBIADATA_CRAWLER_JOBS)
+	TibiaDataCrawlerConfig = "" // can be overridden by env TIBIADATA_CRAWLER_CONFIG
+
+	// TibiaDataCrawlerJitter - the share of the interval of a job added randomly to it
+	TibiaDataCrawlerJitter = 0.1 // can be overridden by env TIBIADATA_CRAWLER_JITTER
+
+	// TibiaDataCharactersConcurrency - amount of characters requested at the same time for the characters endpoint
+	TibiaDataCharactersConcurrency = 5 // can be overridden by env TIBIADATA_CHARACTERS_CONCURRENCY
+
+	// TibiaDataCharactersMaxNames - amount of character names allowed in one request to the characters endpoint
+	TibiaDataCharactersMaxNames = 25 // can be overridden by env TIBIADATA_CHARACTERS_MAX_NAMES
+
 	// TibiaData app details set to release/build on GitHub
 	TibiaDataBuildRelease = "unknown"     // will be set by GitHub Actions (to release number)
 	TibiaDataBuildBuilder = "manual"      // will be set by GitHub Actions
@@ -82,8 +156,11 @@ func init() {
 		log.Printf("[debug] TibiaData API User-Agent: %s", TibiaDataUserAgent)
 	}
 
+	// Creating the client shared by all requests
+	TibiaDataClient = NewTibiaDataHTTPClient(TibiaDataHTTPClientSettings, TibiaDataUserAgent)
+
 	// Initiate the validator
-	err := validation.Initiate(TibiaDataUserAgent)
+	err := validation.InitiateWithTransport(TibiaDataClient.GetClient().Transport, TibiaDataUserAgent)
 	if err != nil {
 		panic(err)
 	}
@@ -115,7 +192,7 @@ func TibiaDataInitializer() {
 		log.Println("[info] TibiaData API protocol: " + TibiaDataProtocol)
 	}
 
-	// Setting TibiaDataProxyDomain
+	// Setting TibiaDataProxies
 	if isEnvExist("TIBIADATA_PROXY") {
 
 		TibiaDataProxyProtocol := getEnv("TIBIADATA_PROXY_PROTOCOL", "https")
@@ -124,9 +201,124 @@ func TibiaDataInitializer() {
 			TibiaDataProxyProtocol = "http"
 		}
 
-		TibiaDataProxyDomain = TibiaDataProxyProtocol + "://" + getEnv("TIBIADATA_PROXY", "www.tibia.com") + "/"
-		log.Printf("[info] TibiaData API proxy: %s", TibiaDataProxyDomain)
+		switch strategy := getEnv("TIBIADATA_PROXY_STRATEGY", TibiaDataProxyStrategy); strategy {
+		case TibiaDataProxyRoundRobin, TibiaDataProxyLeastThrottled:
+			TibiaDataProxyStrategy = strategy
+		default:
+			log.Printf("[warning] TibiaData API proxy strategy %s unknown, using %s", strategy, TibiaDataProxyStrategy)
+		}
+		TibiaDataProxyEjectThreshold = getEnvAsInt("TIBIADATA_PROXY_EJECT_THRESHOLD", TibiaDataProxyEjectThreshold)
+		TibiaDataProxyEjectDuration = getEnvAsDuration("TIBIADATA_PROXY_EJECT_DURATION", TibiaDataProxyEjectDuration)
+		TibiaDataProxyMaxEjectDuration = getEnvAsDuration("TIBIADATA_PROXY_MAX_EJECT_DURATION", TibiaDataProxyMaxEjectDuration)
+		TibiaDataProxyProbeInterval = getEnvAsDuration("TIBIADATA_PROXY_PROBE_INTERVAL", TibiaDataProxyProbeInterval)
+
+		proxies := TibiaDataProxyURLs(getEnv("TIBIADATA_PROXY", "www.tibia.com"), TibiaDataProxyProtocol)
+		TibiaDataProxies = NewTibiaDataProxyPool(proxies, TibiaDataProxyStrategy, TibiaDataProxyEjectThreshold, TibiaDataProxyEjectDuration, TibiaDataProxyMaxEjectDuration)
+		log.Printf("[info] TibiaData API proxies: %s (strategy: %s, eject after %d failures for %s up to %s)", strings.Join(proxies, ", "), TibiaDataProxyStrategy, TibiaDataProxyEjectThreshold, TibiaDataProxyEjectDuration, TibiaDataProxyMaxEjectDuration)
+	}
+
+	// Setting TibiaDataOnlineConcurrency
+	TibiaDataOnlineConcurrency = max(getEnvAsInt("TIBIADATA_ONLINE_CONCURRENCY", TibiaDataOnlineConcurrency), 1)
+	log.Printf("[info] TibiaData API online concurrency: %d", TibiaDataOnlineConcurrency)
+
+	// Setting the cache
+	TibiaDataCacheEnabled = getEnvAsBool("TIBIADATA_CACHE_ENABLED", TibiaDataCacheEnabled)
+	TibiaDataCacheDefaultTTL = getEnvAsDuration("TIBIADATA_CACHE_DEFAULT_TTL", TibiaDataCacheDefaultTTL)
+	if isEnvExist("TIBIADATA_CACHE_TTLS") {
+		ttls, err := tibiaDataCacheParseTTLs(getEnv("TIBIADATA_CACHE_TTLS", ""))
+		if err != nil {
+			log.Printf("[warning] TibiaData API cache ttls are invalid: %s", err)
+		}
+		for handlerName, ttl := range ttls {
+			TibiaDataCacheTTLs[handlerName] = ttl
+		}
 	}
+	TibiaDataCacheDefaultMaxStale = getEnvAsDuration("TIBIADATA_CACHE_MAX_STALE", TibiaDataCacheDefaultMaxStale)
+	if isEnvExist("TIBIADATA_CACHE_MAX_STALES") {
+		maxStales, err := tibiaDataCacheParseTTLs(getEnv("TIBIADATA_CACHE_MAX_STALES", ""))
+		if err != nil {
+			log.Printf("[warning] TibiaData API cache max stales are invalid: %s", err)
+		}
+		for handlerName, maxStale := range maxStales {
+			TibiaDataCacheMaxStales[handlerName] = maxStale
+		}
+	}
+	TibiaDataCacheBackend = getEnv("TIBIADATA_CACHE_BACKEND", TibiaDataCacheBackend)
+	TibiaDataCacheMaxEntries = getEnvAsInt("TIBIADATA_CACHE_MAX_ENTRIES", TibiaDataCacheMaxEntries)
+	if TibiaDataCacheEnabled {
+		cache, err := TibiaDataCacheNew(TibiaDataCacheBackend)
+		if err != nil {
+			log.Printf("[warning] TibiaData API cache backend %s is unavailable, using memory: %s", TibiaDataCacheBackend, err)
+			TibiaDataCacheBackend = "memory"
+			cache = NewTibiaDataCacheMemory(TibiaDataCacheMaxEntries)
+		}
+		TibiaDataCache = cache
+		go TibiaDataCacheJanitor(time.Minute)
+	}
+	log.Printf("[info] TibiaData API cache enabled: %t (backend: %s, default ttl: %s, default max stale: %s)", TibiaDataCacheEnabled, TibiaDataCacheBackend, TibiaDataCacheDefaultTTL, TibiaDataCacheDefaultMaxStale)
+
+	// Setting the rate limiter
+	TibiaDataRateLimit = getEnvAsFloat("TIBIADATA_RATE_LIMIT", TibiaDataRateLimit)
+	TibiaDataRateLimitBurst = getEnvAsInt("TIBIADATA_RATE_LIMIT_BURST", TibiaDataRateLimitBurst)
+	TibiaDataRateLimitMaxWait = getEnvAsDuration("TIBIADATA_RATE_LIMIT_MAX_WAIT", TibiaDataRateLimitMaxWait)
+	TibiaDataLimiter = NewTibiaDataRateLimiter(TibiaDataRateLimit, TibiaDataRateLimitBurst, TibiaDataRateLimitMaxWait)
+	if TibiaDataRateLimit > 0 {
+		log.Printf("[info] TibiaData API rate limit: %g requests/s (burst: %d, max wait: %s)", TibiaDataRateLimit, TibiaDataRateLimitBurst, TibiaDataRateLimitMaxWait)
+	}
+
+	// Setting the circuit breaker
+	TibiaDataBreakerThreshold = getEnvAsInt("TIBIADATA_BREAKER_THRESHOLD", TibiaDataBreakerThreshold)
+	TibiaDataBreakerCooldown = getEnvAsDuration("TIBIADATA_BREAKER_COOLDOWN", TibiaDataBreakerCooldown)
+	TibiaDataBreakerMaxCooldown = getEnvAsDuration("TIBIADATA_BREAKER_MAX_COOLDOWN", TibiaDataBreakerMaxCooldown)
+	TibiaDataBreaker = NewTibiaDataCircuitBreaker(TibiaDataBreakerThreshold, TibiaDataBreakerCooldown, TibiaDataBreakerMaxCooldown)
+	log.Printf("[info] TibiaData API circuit breaker: %d failures (cooldown: %s, max cooldown: %s)", TibiaDataBreakerThreshold, TibiaDataBreakerCooldown, TibiaDataBreakerMaxCooldown)
+
+	// Setting the http client
+	TibiaDataHTTPClientSettings = TibiaDataHTTPClientConfigFromEnv(TibiaDataHTTPClientSettings)
+	log.Printf("[info] TibiaData API http client: timeout %s, %d retries (max idle conns per host: %d, http2: %t)", TibiaDataHTTPClientSettings.Timeout, TibiaDataHTTPClientSettings.RetryCount, TibiaDataHTTPClientSettings.MaxIdleConnsPerHost, TibiaDataHTTPClientSettings.HTTP2)
+
+	// Setting the history
+	TibiaDataHistoryDir = getEnv("TIBIADATA_HISTORY_DIR", TibiaDataHistoryDir)
+	TibiaDataHistoryInterval = getEnvAsDuration("TIBIADATA_HISTORY_INTERVAL", TibiaDataHistoryInterval)
+	TibiaDataHistoryRetention = getEnvAsDuration("TIBIADATA_HISTORY_RETENTION", TibiaDataHistoryRetention)
+	if TibiaDataHistoryDir != "" {
+		history, err := NewTibiaDataHistoryStore(TibiaDataHistoryDir, TibiaDataHistoryInterval, TibiaDataHistoryRetention)
+		if err != nil {
+			log.Printf("[warning] TibiaData API history is unavailable: %s", err)
+		} else {
+			TibiaDataHistory = history
+			log.Printf("[info] TibiaData API history: %s (interval: %s, retention: %s)", TibiaDataHistoryDir, TibiaDataHistoryInterval, TibiaDataHistoryRetention)
+		}
+	}
+
+	// Setting the crawler
+	TibiaDataCrawlerConfig = getEnv("TIBIADATA_CRAWLER_CONFIG", TibiaDataCrawlerConfig)
+	TibiaDataCrawlerJitter = getEnvAsFloat("TIBIADATA_CRAWLER_JITTER", TibiaDataCrawlerJitter)
+	if TibiaDataCrawlerConfig != "" || isEnvExist("TIBIADATA_CRAWLER_JOBS") {
+		var (
+			jobs []TibiaDataCrawlerJob
+			err  error
+		)
+		if TibiaDataCrawlerConfig != "" {
+			jobs, err = TibiaDataCrawlerJobsFromFile(TibiaDataCrawlerConfig, TibiaDataCrawlerJitter)
+		} else {
+			jobs, err = TibiaDataCrawlerJobsFromEnv(getEnv("TIBIADATA_CRAWLER_JOBS", ""), TibiaDataCrawlerJitter)
+		}
+
+		if err != nil {
+			log.Printf("[warning] TibiaData API crawler jobs are invalid: %s", err)
+		} else {
+			TibiaDataCrawler = NewTibiaDataCrawlerScheduler(jobs)
+			for _, job := range jobs {
+				log.Printf("[info] TibiaData API crawler job %s: %s every %s (jitter: %s)", job.Name, job.Path, job.Interval, job.Jitter)
+			}
+		}
+	}
+
+	// Setting TibiaDataCharactersConcurrency and TibiaDataCharactersMaxNames
+	TibiaDataCharactersConcurrency = max(getEnvAsInt("TIBIADATA_CHARACTERS_CONCURRENCY", TibiaDataCharactersConcurrency), 1)
+	TibiaDataCharactersMaxNames = max(getEnvAsInt("TIBIADATA_CHARACTERS_MAX_NAMES", TibiaDataCharactersMaxNames), 1)
+	log.Printf("[info] TibiaData API characters concurrency: %d (max names: %d)", TibiaDataCharactersConcurrency, TibiaDataCharactersMaxNames)
 
 	// Run some functions that are empty but required for documentation to be done
 	_ = tibiaNewslistArchive()
@@ -29,11 +29,19 @@ const (
 
 // Run is used to load data from the assets JSON file
 func Run(userAgent string) (TibiaMapping, error) {
+	return RunWithTransport(nil, userAgent)
+}
+
+// RunWithTransport is used to load data from the assets JSON file through the transport (nil uses the default one)
+func RunWithTransport(transport http.RoundTripper, userAgent string) (TibiaMapping, error) {
 	// Logging the start of tibiamapping
 	log.Println("[info] Tibia Mapping is running")
 
 	// Setting up resty client
 	client := resty.New()
+	if transport != nil {
+		client.SetTransport(transport)
+	}
 
 	// Set client timeout  and retry
 	client.SetTimeout(5 * time.Second)
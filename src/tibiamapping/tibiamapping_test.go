@@ -2,6 +2,9 @@ package tibiamapping
 
 import (
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 )
 
@@ -37,3 +40,49 @@ func TestInitiator(t *testing.T) {
 		t.Fatal(errors.New("Sha512Sum is empty"))
 	}
 }
+
+type testTransport struct {
+	target *url.URL
+}
+
+// RoundTrip sends all requests to the test server
+func (tr testTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = tr.target.Scheme
+	req.URL.Host = tr.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestRunWithTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != "TibiaData-API/v4/testing" {
+			t.Errorf("User-Agent is %q", r.Header.Get("User-Agent"))
+		}
+
+		switch r.URL.Path {
+		case "/data.min.json":
+			_, _ = w.Write([]byte(`{"worlds":[]}`))
+		case "/sha256sum.txt":
+			_, _ = w.Write([]byte("sha256"))
+		case "/sha512sum.txt":
+			_, _ = w.Write([]byte("sha512"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	target, _ := url.Parse(server.URL)
+	mapping, err := RunWithTransport(testTransport{target: target}, "TibiaData-API/v4/testing")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(mapping.RawData) != `{"worlds":[]}` {
+		t.Fatalf("RawData is %q", mapping.RawData)
+	}
+
+	if mapping.Sha256Sum != "sha256" || mapping.Sha512Sum != "sha512" {
+		t.Fatalf("Sha256Sum is %q and Sha512Sum is %q", mapping.Sha256Sum, mapping.Sha512Sum)
+	}
+}
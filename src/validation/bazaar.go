@@ -0,0 +1,87 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// validBazaarSkills stores all valid skills the char bazaar can be filtered on
+	validBazaarSkills = []string{"axe", "axefighting", "club", "clubfighting", "distance", "distancefighting", "fishing", "fist", "fistfighting", "magic", "mlvl", "magiclevel", "shielding", "shield", "sword", "swordfighting"}
+)
+
+// IsBazaarSkillValid reports wheter the provided string represents a valid char bazaar skill
+// Check if error == nil to see whether the skill is valid or not
+func IsBazaarSkillValid(skill string) error {
+	for _, s := range validBazaarSkills {
+		if strings.EqualFold(skill, s) {
+			return nil
+		}
+	}
+
+	return ErrorBazaarSkillDoesNotExist
+}
+
+// BazaarSkill is the id tibia.com uses for a skill in the char bazaar filters
+type BazaarSkill int
+
+const (
+	BazaarSkillMagiclevel       BazaarSkill = 1
+	BazaarSkillShielding        BazaarSkill = 6
+	BazaarSkillDistancefighting BazaarSkill = 7
+	BazaarSkillSwordfighting    BazaarSkill = 8
+	BazaarSkillClubfighting     BazaarSkill = 9
+	BazaarSkillAxefighting      BazaarSkill = 10
+	BazaarSkillFistfighting     BazaarSkill = 11
+	BazaarSkillFishing          BazaarSkill = 13
+)
+
+func (bs BazaarSkill) String() (string, error) {
+	switch bs {
+	case BazaarSkillMagiclevel:
+		return "magiclevel", nil
+	case BazaarSkillShielding:
+		return "shielding", nil
+	case BazaarSkillDistancefighting:
+		return "distancefighting", nil
+	case BazaarSkillSwordfighting:
+		return "swordfighting", nil
+	case BazaarSkillClubfighting:
+		return "clubfighting", nil
+	case BazaarSkillAxefighting:
+		return "axefighting", nil
+	case BazaarSkillFistfighting:
+		return "fistfighting", nil
+	case BazaarSkillFishing:
+		return "fishing", nil
+	default:
+		return "", errors.New("invalid BazaarSkill value")
+	}
+}
+
+// BazaarSkillFromString returns the BazaarSkill of the provided string
+// Make sure to check the string with IsBazaarSkillValid first, as 0 is returned for unknown skills
+func BazaarSkillFromString(input string) BazaarSkill {
+	// Sanatize of skill value
+	input = strings.ToLower(input)
+	switch input {
+	case "magic", "mlvl", "magiclevel":
+		return BazaarSkillMagiclevel
+	case "shielding", "shield":
+		return BazaarSkillShielding
+	case "distance", "distancefighting":
+		return BazaarSkillDistancefighting
+	case "sword", "swordfighting":
+		return BazaarSkillSwordfighting
+	case "club", "clubfighting":
+		return BazaarSkillClubfighting
+	case "axe", "axefighting":
+		return BazaarSkillAxefighting
+	case "fist", "fistfighting":
+		return BazaarSkillFistfighting
+	case "fishing":
+		return BazaarSkillFishing
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,49 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBazaarSkillValidator(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, skill := range []string{"axe", "Club", "DISTANCE", "fishing", "fistfighting", "mlvl", "shield", "sword"} {
+		assert.Nil(IsBazaarSkillValid(skill), skill)
+	}
+
+	assert.Equal(ErrorBazaarSkillDoesNotExist, IsBazaarSkillValid("experience"))
+	assert.Equal(ErrorBazaarSkillDoesNotExist, IsBazaarSkillValid(""))
+}
+
+func TestBazaarSkillFromString(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected BazaarSkill
+		name     string
+	}{
+		"Magic":    {input: "magic", expected: BazaarSkillMagiclevel, name: "magiclevel"},
+		"Shield":   {input: "Shield", expected: BazaarSkillShielding, name: "shielding"},
+		"Distance": {input: "distance", expected: BazaarSkillDistancefighting, name: "distancefighting"},
+		"Sword":    {input: "swordfighting", expected: BazaarSkillSwordfighting, name: "swordfighting"},
+		"Club":     {input: "club", expected: BazaarSkillClubfighting, name: "clubfighting"},
+		"Axe":      {input: "AXE", expected: BazaarSkillAxefighting, name: "axefighting"},
+		"Fist":     {input: "fist", expected: BazaarSkillFistfighting, name: "fistfighting"},
+		"Fishing":  {input: "fishing", expected: BazaarSkillFishing, name: "fishing"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			skill := BazaarSkillFromString(tc.input)
+			assert.Equal(t, tc.expected, skill)
+
+			str, err := skill.String()
+			assert.Nil(t, err)
+			assert.Equal(t, tc.name, str)
+		})
+	}
+
+	_, err := BazaarSkillFromString("experience").String()
+	assert.NotNil(t, err)
+}
@@ -22,6 +22,18 @@ var (
 	// Code: 11
 	ErrorValidatorNotInitiated = Error{errors.New("validator func called but the validator has not been initiated")}
 
+	// ErrorUpstreamRateLimited will be sent if a request to tibia.com would have to wait too long for the rate limiter
+	// Code: 12
+	ErrorUpstreamRateLimited = Error{errors.New("too many requests to tibia.com are queued, please try again later")}
+
+	// ErrorUpstreamCircuitOpen will be sent if requests to tibia.com are paused after repeated failures
+	// Code: 13
+	ErrorUpstreamCircuitOpen = Error{errors.New("requests to tibia.com are paused after repeated failures, please try again later")}
+
+	// ErrorUpstreamProxiesEjected will be sent if all proxies to tibia.com are ejected after repeated failures
+	// Code: 14
+	ErrorUpstreamProxiesEjected = Error{errors.New("all proxies to tibia.com are paused after repeated failures, please try again later")}
+
 	////////////////////
 	/// User Errors ///
 	//////////////////
@@ -34,6 +46,18 @@ var (
 	// Code: 9002
 	ErrorRestrictionMode = Error{errors.New("the provided page is not available due to restriction mode")}
 
+	// ErrorHistoryDisabled will be sent if the history is requested but not enabled
+	// Code: 9003
+	ErrorHistoryDisabled = Error{errors.New("the history is not enabled on this server")}
+
+	// ErrorHistoryRangeInvalid will be sent if the time range of the history request is invalid
+	// Code: 9004
+	ErrorHistoryRangeInvalid = Error{errors.New("the provided time range is invalid")}
+
+	// ErrorHistoryPeriodInvalid will be sent if the period of the progress request is invalid
+	// Code: 9005
+	ErrorHistoryPeriodInvalid = Error{errors.New("the provided period is invalid, it must be day or week")}
+
 	// ErrorCharacterNameEmpty will be sent if the request contains an empty character name
 	// Code: 10001
 	ErrorCharacterNameEmpty = Error{errors.New("the provided character name is an empty string")}
@@ -62,6 +86,27 @@ var (
 	// Code: 10007
 	ErrorCharacterWordTooSmall = Error{errors.New("the provided character name has a word too small")}
 
+	// ErrorCharacterNamesEmpty will be sent if the request for multiple characters contains no character names
+	// Code: 10008
+	ErrorCharacterNamesEmpty = Error{errors.New("the provided list of character names is empty or invalid")}
+
+	// ErrorCharacterNamesTooMany will be sent if the request for multiple characters contains more than the allowed amount of character names
+	// Code: 10009
+	ErrorCharacterNamesTooMany = Error{errors.New("the provided list of character names is too big")}
+
+	// ErrorCharacterRenamed will be sent if the requested character name is a former name and former names should not be followed
+	// Code: 10010
+	ErrorCharacterRenamed = Error{errors.New("the requested character has been renamed")}
+
+	// ErrorCharacterDeleted will be sent if the requested character does not exist anymore, but was recorded before (deleted or banished)
+	// Code: 10011
+	ErrorCharacterDeleted = Error{errors.New("the requested character has been deleted or banished")}
+
+	// ErrorCharacterStateUnknown will be sent if the requested character does not exist and the history is disabled,
+	// so it is unknown whether it was deleted or banished or never existed
+	// Code: 10012
+	ErrorCharacterStateUnknown = Error{errors.New("could not find character, whether it was deleted or never existed is unknown without the history")}
+
 	// ErrorInvalidNewsID will be sent if the request contains an invalid news ID
 	// Code: 11001
 	ErrorInvalidNewsID = Error{errors.New("the provided news id is invalid")}
@@ -94,6 +139,34 @@ var (
 	// Code: 11008
 	ErrorHighscorePageTooBig = Error{errors.New("the provided page is larger than max amount of pages")}
 
+	// ErrorBazaarSkillDoesNotExist will be sent if the request contains a char bazaar skill that does not exist
+	// Code: 11009
+	ErrorBazaarSkillDoesNotExist = Error{errors.New("the provided bazaar skill does not exist")}
+
+	// ErrorLevelRangeInvalid will be sent if the request contains a level range that is negative or where from is bigger than to
+	// Code: 11010
+	ErrorLevelRangeInvalid = Error{errors.New("the provided level range is invalid")}
+
+	// ErrorSkillRangeInvalid will be sent if the request contains a skill range that is negative or where from is bigger than to
+	// Code: 11011
+	ErrorSkillRangeInvalid = Error{errors.New("the provided skill range is invalid")}
+
+	// ErrorEventScheduleDateInvalid will be sent if the request contains a month that is not between 1 and 12 or an invalid year
+	// Code: 11012
+	ErrorEventScheduleDateInvalid = Error{errors.New("the provided year or month is invalid")}
+
+	// ErrorInvalidPollID will be sent if the request contains an invalid poll ID
+	// Code: 11013
+	ErrorInvalidPollID = Error{errors.New("the provided poll id is invalid")}
+
+	// ErrorPageInvalid will be sent if the page of a paginated list is not valid
+	// Code: 11014
+	ErrorPageInvalid = Error{errors.New("the provided page is invalid")}
+
+	// ErrorSkillRangeWithoutSkill will be sent if the request contains a skill range but no skill
+	// Code: 11015
+	ErrorSkillRangeWithoutSkill = Error{errors.New("the provided skill range requires a skill")}
+
 	// ErrorCreatureNameEmpty will be sent if the request contains an empty creature name
 	// Code: 12001
 	ErrorCreatureNameEmpty = Error{errors.New("the provided creature name is an empty string")}
@@ -216,6 +289,22 @@ var (
 	// ErrStatusUnknown will be sent a HTTP request we are not expecting.
 	// Code: 20008
 	ErrStatusUnknown = Error{errors.New("got unknown status from tibia.com")}
+
+	// ErrorAuctionNotFound will be sent if the requested char bazaar auction does not exist
+	// Code: 20009
+	ErrorAuctionNotFound = Error{errors.New("could not find auction")}
+
+	// ErrorAchievementNotFound will be sent if the requested achievement does not exist
+	// Code: 20010
+	ErrorAchievementNotFound = Error{errors.New("could not find achievement")}
+
+	// ErrorWorldsPartiallyUnavailable will be sent if only some of the requested worlds could be fetched from tibia.com
+	// Code: 20011
+	ErrorWorldsPartiallyUnavailable = Error{errors.New("could not fetch all worlds from tibia.com")}
+
+	// ErrorPollNotFound will be sent if the requested poll does not exist
+	// Code: 20012
+	ErrorPollNotFound = Error{errors.New("could not find poll")}
 )
 
 // Code will return the code of the error
@@ -225,10 +314,22 @@ func (e Error) Code() int {
 		return 10
 	case ErrorValidatorNotInitiated:
 		return 11
+	case ErrorUpstreamRateLimited:
+		return 12
+	case ErrorUpstreamCircuitOpen:
+		return 13
+	case ErrorUpstreamProxiesEjected:
+		return 14
 	case ErrorStringCanNotBeConvertedToInt:
 		return 9001
 	case ErrorRestrictionMode:
 		return 9002
+	case ErrorHistoryDisabled:
+		return 9003
+	case ErrorHistoryRangeInvalid:
+		return 9004
+	case ErrorHistoryPeriodInvalid:
+		return 9005
 	case ErrorCharacterNameEmpty:
 		return 10001
 	case ErrorCharacterNameTooSmall:
@@ -243,6 +344,16 @@ func (e Error) Code() int {
 		return 10006
 	case ErrorCharacterWordTooSmall:
 		return 10007
+	case ErrorCharacterNamesEmpty:
+		return 10008
+	case ErrorCharacterNamesTooMany:
+		return 10009
+	case ErrorCharacterRenamed:
+		return 10010
+	case ErrorCharacterDeleted:
+		return 10011
+	case ErrorCharacterStateUnknown:
+		return 10012
 	case ErrorInvalidNewsID:
 		return 11001
 	case ErrorWorldDoesNotExist:
@@ -259,6 +370,20 @@ func (e Error) Code() int {
 		return 11007
 	case ErrorHighscorePageTooBig:
 		return 11008
+	case ErrorBazaarSkillDoesNotExist:
+		return 11009
+	case ErrorLevelRangeInvalid:
+		return 11010
+	case ErrorSkillRangeInvalid:
+		return 11011
+	case ErrorEventScheduleDateInvalid:
+		return 11012
+	case ErrorInvalidPollID:
+		return 11013
+	case ErrorPageInvalid:
+		return 11014
+	case ErrorSkillRangeWithoutSkill:
+		return 11015
 	case ErrorCreatureNameEmpty:
 		return 12001
 	case ErrorCreatureNameTooSmall:
@@ -317,6 +442,14 @@ func (e Error) Code() int {
 		return 20007
 	case ErrStatusUnknown:
 		return 20008
+	case ErrorAuctionNotFound:
+		return 20009
+	case ErrorAchievementNotFound:
+		return 20010
+	case ErrorWorldsPartiallyUnavailable:
+		return 20011
+	case ErrorPollNotFound:
+		return 20012
 	default:
 		return 0
 	}
@@ -48,6 +48,17 @@ func IsNewsIDValid(ID int) error {
 	return nil
 }
 
+// IsPollIDValid reports wheter the provided int represents a valid poll ID
+// Check if error == nil to see whether the ID is valid or not
+func IsPollIDValid(ID int) error {
+	// If the ID is 0 or a negative number it is invalid
+	if ID <= 0 {
+		return ErrorInvalidPollID
+	}
+
+	return nil
+}
+
 // IsVocationValid reports wheter the provided string represents a valid vocation
 // Check if error == nil to see whether the vocation is valid or not
 func IsVocationValid(vocation string) error {
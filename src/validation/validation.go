@@ -3,6 +3,7 @@ package validation
 import (
 	"encoding/json"
 	"errors"
+	"net/http"
 	"strings"
 	"sync"
 	"unicode/utf8"
@@ -53,6 +54,11 @@ var (
 
 // Initiate initiates the validator, this should be called on the init() func
 func Initiate(TibiaDataUserAgent string) error {
+	return InitiateWithTransport(nil, TibiaDataUserAgent)
+}
+
+// InitiateWithTransport initiates the validator and loads the assets through the transport (nil uses the default one)
+func InitiateWithTransport(transport http.RoundTripper, TibiaDataUserAgent string) error {
 	// Make sure InitiateValidator can not be called concurrently
 	locker.Lock()
 	defer locker.Unlock()
@@ -64,7 +70,7 @@ func Initiate(TibiaDataUserAgent string) error {
 	}
 
 	// Get the assets
-	tibiaMapping, err := tibiamapping.Run(TibiaDataUserAgent)
+	tibiaMapping, err := tibiamapping.RunWithTransport(transport, TibiaDataUserAgent)
 	if err != nil {
 		panic(err)
 	}
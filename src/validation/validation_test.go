@@ -482,12 +482,30 @@ func TestErrors(t *testing.T) {
 		ErrorValidatorNotInitiated: {
 			Code: 11,
 		},
+		ErrorUpstreamRateLimited: {
+			Code: 12,
+		},
+		ErrorUpstreamCircuitOpen: {
+			Code: 13,
+		},
+		ErrorUpstreamProxiesEjected: {
+			Code: 14,
+		},
 		ErrorStringCanNotBeConvertedToInt: {
 			Code: 9001,
 		},
 		ErrorRestrictionMode: {
 			Code: 9002,
 		},
+		ErrorHistoryDisabled: {
+			Code: 9003,
+		},
+		ErrorHistoryRangeInvalid: {
+			Code: 9004,
+		},
+		ErrorHistoryPeriodInvalid: {
+			Code: 9005,
+		},
 		ErrorCharacterNameEmpty: {
 			Code: 10001,
 		},
@@ -509,6 +527,21 @@ func TestErrors(t *testing.T) {
 		ErrorCharacterWordTooSmall: {
 			Code: 10007,
 		},
+		ErrorCharacterNamesEmpty: {
+			Code: 10008,
+		},
+		ErrorCharacterNamesTooMany: {
+			Code: 10009,
+		},
+		ErrorCharacterRenamed: {
+			Code: 10010,
+		},
+		ErrorCharacterDeleted: {
+			Code: 10011,
+		},
+		ErrorCharacterStateUnknown: {
+			Code: 10012,
+		},
 		ErrorInvalidNewsID: {
 			Code: 11001,
 		},
@@ -533,6 +566,27 @@ func TestErrors(t *testing.T) {
 		ErrorHighscorePageTooBig: {
 			Code: 11008,
 		},
+		ErrorBazaarSkillDoesNotExist: {
+			Code: 11009,
+		},
+		ErrorLevelRangeInvalid: {
+			Code: 11010,
+		},
+		ErrorSkillRangeInvalid: {
+			Code: 11011,
+		},
+		ErrorEventScheduleDateInvalid: {
+			Code: 11012,
+		},
+		ErrorInvalidPollID: {
+			Code: 11013,
+		},
+		ErrorPageInvalid: {
+			Code: 11014,
+		},
+		ErrorSkillRangeWithoutSkill: {
+			Code: 11015,
+		},
 		ErrorCreatureNameEmpty: {
 			Code: 12001,
 		},
@@ -611,6 +665,18 @@ func TestErrors(t *testing.T) {
 		ErrorMaintenanceMode: {
 			Code: 20005,
 		},
+		ErrorAuctionNotFound: {
+			Code: 20009,
+		},
+		ErrorAchievementNotFound: {
+			Code: 20010,
+		},
+		ErrorWorldsPartiallyUnavailable: {
+			Code: 20011,
+		},
+		ErrorPollNotFound: {
+			Code: 20012,
+		},
 	}
 
 	for err, values := range errs {
@@ -889,6 +955,23 @@ func TestNewsIDValidator(t *testing.T) {
 	}
 }
 
+func TestPollIDValidator(t *testing.T) {
+	err := IsPollIDValid(1)
+	if err != nil {
+		t.Fatalf("Poll ID 1 is being reported as invalid but should be valid, err: %s", err)
+	}
+
+	err = IsPollIDValid(0)
+	if err == nil {
+		t.Fatal("Poll ID 0 is being reported as valid but should be invalid")
+	}
+
+	err = IsPollIDValid(-1)
+	if err == nil {
+		t.Fatal("Poll ID -1 is being reported as valid but should be invalid")
+	}
+}
+
 func TestVocationValidator(t *testing.T) {
 	err := IsVocationValid("tibia")
 	if err == nil {
@@ -2,10 +2,12 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
 	"os"
 	"os/signal"
@@ -26,7 +28,7 @@ import (
 
 var (
 	// TibiaData app resty vars
-	TibiaDataUserAgent, TibiaDataProxyDomain string
+	TibiaDataUserAgent string
 
 	// ErrorNotFound will be returned if the requests ends up in a 404
 	ErrorNotFound = errors.New("page not found")
@@ -63,6 +65,7 @@ type Status struct {
 	HTTPCode int    `json:"http_code"`         // The HTTP response code from the API.
 	Error    int    `json:"error,omitempty"`   // The error code thrown by TibiaData API for identification of issue.
 	Message  string `json:"message,omitempty"` // The error message thrown by TibiaData API for human readability.
+	Stale    bool   `json:"stale,omitempty"`   // Whether the data is outdated, because tibia.com could not be reached.
 }
 
 // TibiaDataRequest is the struct of request information
@@ -71,6 +74,16 @@ type TibiaDataRequestStruct struct {
 	URL      string            `json:"url"`       // Request URL
 	FormData map[string]string `json:"form_data"` // Request form content (used when POST)
 	RawBody  bool              `json:"raw_body"`  // If set to true the whole content from tibia.com will be passed down
+	Priority TibiaDataPriority `json:"priority"`  // Request priority when requests to tibia.com are rate limited (default: normal)
+	Refresh  bool              `json:"refresh"`   // If set to true tibia.com is requested even if the content is cached (e.g. by the crawler)
+}
+
+// tibiaDataEndpointRequest is the request to tibia.com of an endpoint with the parser of its content
+// it is shared by the handler of the endpoint and the crawler
+type tibiaDataEndpointRequest struct {
+	Request     TibiaDataRequestStruct                           // The request to tibia.com.
+	Parser      func(BoxContentHTML string) (interface{}, error) // Parses the content of tibia.com into the response.
+	HandlerName string                                           // The name of the handler, e.g. used by the history.
 }
 
 // RunWebServer starts the gin server
@@ -144,6 +157,9 @@ func runWebServer() {
 	// Set the debug endpoint
 	router.GET("/debug", debugHandler)
 
+	// Set the admin endpoints
+	router.GET("/admin/crawler", crawlerHandler)
+
 	// TibiaData API version 3 endpoints
 	router.GET("/v3/*action", func(c *gin.Context) {
 		c.JSON(299, gin.H{
@@ -158,23 +174,39 @@ func runWebServer() {
 	// TibiaData API version 4 endpoints
 	v4 := router.Group("/v4")
 	{
+		// Tibia achievements
+		v4.GET("/achievement/:name", tibiaAchievementsAchievement)
+		v4.GET("/achievements", tibiaAchievementsOverview)
+
+		// Tibia char bazaar
+		v4.GET("/bazaar/auction/:id", tibiaBazaarAuction)
+		v4.GET("/bazaar/auctions", tibiaBazaarAuctions)
+		v4.GET("/bazaar/history", tibiaBazaarHistory)
+
 		// Tibia characters
 		v4.GET("/boostablebosses", tibiaBoostableBosses)
 
 		// Tibia characters
 		v4.GET("/character/:name", tibiaCharactersCharacter)
+		v4.GET("/character/:name/progress", tibiaCharactersProgress)
+		v4.POST("/characters", tibiaCharactersCharacters)
 
 		// Tibia creatures
 		v4.GET("/creature/:race", tibiaCreaturesCreature)
 		v4.GET("/creatures", tibiaCreaturesOverview)
 
+		// Tibia events
+		v4.GET("/events/active", tibiaEventsActive)
+		v4.GET("/events/:year/:month", tibiaEventsSchedule)
+
 		// Tibia fansites
 		v4.GET("/fansites", tibiaFansites)
 
 		// Tibia guilds
 		v4.GET("/guild/:name", tibiaGuildsGuild)
-		// v4.GET("/guild/:name/events",TibiaGuildsGuildEvents)
-		// v4.GET("/guild/:name/wars",TibiaGuildsGuildWars)
+		v4.GET("/guild/:name/changes", tibiaGuildsGuildChanges)
+		v4.GET("/guild/:name/events", tibiaGuildsGuildEvents)
+		v4.GET("/guild/:name/wars", tibiaGuildsGuildWars)
 		v4.GET("/guilds/:world", tibiaGuildsOverview)
 
 		// Tibia highscores
@@ -187,6 +219,12 @@ func runWebServer() {
 		v4.GET("/highscores/:world/:category/:vocation", tibiaHighscores)
 		v4.GET("/highscores/:world/:category/:vocation/:page", tibiaHighscores)
 
+		// Tibia history
+		v4.GET("/history/guild/:name", tibiaHistoryGuild)
+		v4.GET("/history/highscores/:world/:category/:vocation/:page", tibiaHistoryHighscores)
+		v4.GET("/history/killstatistics/:world", tibiaHistoryKillstatistics)
+		v4.GET("/history/world/:name", tibiaHistoryWorld)
+
 		// Tibia houses
 		v4.GET("/house/:world/:house_id", tibiaHousesHouse)
 		v4.GET("/houses/:world/:town", tibiaHousesOverview)
@@ -194,6 +232,13 @@ func runWebServer() {
 		// Tibia killstatistics
 		v4.GET("/killstatistics/:world", tibiaKillstatistics)
 
+		// Tibia leaderboards
+		v4.GET("/leaderboards/:world", tibiaLeaderboards)
+
+		// Tibia library
+		v4.GET("/library/experience", tibiaLibraryExperienceTable)
+		v4.GET("/library/experience/:from/:to", tibiaLibraryExperienceRange)
+
 		// Tibia news
 		v4.GET("/news/archive", tibiaNewslist)       // all categories (default 90 days)
 		v4.GET("/news/archive/:days", tibiaNewslist) // all categories
@@ -201,6 +246,13 @@ func runWebServer() {
 		v4.GET("/news/latest", tibiaNewslist)        // only news and articles
 		v4.GET("/news/newsticker", tibiaNewslist)    // only news_ticker
 
+		// Tibia online characters
+		v4.GET("/online", tibiaWorldsOnline)
+
+		// Tibia polls
+		v4.GET("/poll/:id", tibiaPoll)
+		v4.GET("/polls", tibiaPollslist)
+
 		// Tibia spells
 		v4.GET("/spell/:spell_id", tibiaSpellsSpell)
 		v4.GET("/spells", tibiaSpellsOverview)
@@ -239,6 +291,12 @@ func runWebServer() {
 		}
 	}()
 
+	// Probing ejected proxies, so they are used again once they recovered
+	go TibiaDataProxies.RunProbes(context.Background(), TibiaDataProxyProbeInterval, tibiaDataProxyProbe)
+
+	// Starting the crawler, which refreshes the content of the endpoints of its jobs
+	TibiaDataCrawler.Start(context.Background())
+
 	// setting readyz endpoint to true
 	isReady.Store(true)
 
@@ -254,6 +312,194 @@ func runWebServer() {
 	}
 }
 
+// Achievements godoc
+// @Summary      List of achievements
+// @Description  Show all achievements of the achievements library
+// @Tags         achievements
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  AchievementsOverviewResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Failure      503  {object}  Information
+// @Router       /v4/achievements [get]
+func tibiaAchievementsOverview(c *gin.Context) {
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method: resty.MethodGet,
+		URL:    "https://www.tibia.com/library/?subtopic=achievements",
+	}
+
+	tibiaDataRequestHandler(
+		c,
+		tibiadataRequest,
+		func(BoxContentHTML string) (interface{}, error) {
+			return TibiaAchievementsOverviewImpl(BoxContentHTML, tibiadataRequest.URL)
+		},
+		"TibiaAchievementsOverview")
+}
+
+// Achievement godoc
+// @Summary      Show one achievement
+// @Description  Show all information about one achievement of the achievements library
+// @Tags         achievements
+// @Accept       json
+// @Produce      json
+// @Param        name path string true "The name of the achievement" extensions(x-example=Annihilator)
+// @Success      200  {object}  AchievementResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Failure      503  {object}  Information
+// @Router       /v4/achievement/{name} [get]
+func tibiaAchievementsAchievement(c *gin.Context) {
+	// getting params from URL
+	achievement := strings.TrimSpace(c.Param("name"))
+
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method: resty.MethodGet,
+		URL:    "https://www.tibia.com/library/?subtopic=achievements",
+	}
+
+	tibiaDataRequestHandler(
+		c,
+		tibiadataRequest,
+		func(BoxContentHTML string) (interface{}, error) {
+			return TibiaAchievementsAchievementImpl(achievement, BoxContentHTML, tibiadataRequest.URL)
+		},
+		"TibiaAchievementsAchievement")
+}
+
+// Bazaar auction godoc
+// @Summary      Show one char bazaar auction
+// @Description  Show all details of one character auction of the char bazaar
+// @Tags         bazaar
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "The ID of the auction" extensions(x-example=1513302)
+// @Success      200  {object}  BazaarAuctionDetailsResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Failure      503  {object}  Information
+// @Router       /v4/bazaar/auction/{id} [get]
+func tibiaBazaarAuction(c *gin.Context) {
+	// getting params from URL
+	auctionidStr := c.Param("id")
+
+	auctionid, err := strconv.Atoi(auctionidStr)
+	if err != nil {
+		TibiaDataErrorHandler(c, validation.ErrorStringCanNotBeConvertedToInt, http.StatusBadRequest)
+		return
+	}
+
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method: resty.MethodGet,
+		URL:    "https://www.tibia.com/charactertrade/?subtopic=currentcharactertrades&page=details&auctionid=" + strconv.Itoa(auctionid) + "&source=overview",
+	}
+
+	tibiaDataRequestHandler(
+		c,
+		tibiadataRequest,
+		func(BoxContentHTML string) (interface{}, error) {
+			return TibiaBazaarAuctionImpl(BoxContentHTML, tibiadataRequest.URL)
+		},
+		"TibiaBazaarAuction")
+}
+
+// Bazaar auctions godoc
+// @Summary      List of current char bazaar auctions
+// @Description  Show all current character auctions of the char bazaar, optionally filtered
+// @Tags         bazaar
+// @Accept       json
+// @Produce      json
+// @Param        world      query string false "The world to filter on" extensions(x-example=Antica)
+// @Param        vocation   query string false "The vocation to filter on" extensions(x-example=knight)
+// @Param        level_from query int    false "The minimum level" extensions(x-example=100)
+// @Param        level_to   query int    false "The maximum level" extensions(x-example=500)
+// @Param        skill      query string false "The skill to filter on" extensions(x-example=magiclevel)
+// @Param        skill_from query int    false "The minimum skill level" extensions(x-example=50)
+// @Param        skill_to   query int    false "The maximum skill level" extensions(x-example=100)
+// @Param        page       query int    false "The current page" default(1) minimum(1) extensions(x-example=1)
+// @Success      200  {object}  BazaarAuctionsResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Failure      503  {object}  Information
+// @Router       /v4/bazaar/auctions [get]
+func tibiaBazaarAuctions(c *gin.Context) {
+	// Validate the filters
+	filters, query, err := tibiaBazaarFiltersFromQuery(c)
+	if err != nil {
+		TibiaDataErrorHandler(c, err, http.StatusBadRequest)
+		return
+	}
+
+	// Checking the page provided
+	page := c.DefaultQuery("page", "1")
+	if TibiaDataStringToInteger(page) < 1 {
+		TibiaDataErrorHandler(c, validation.ErrorPageInvalid, http.StatusBadRequest)
+		return
+	}
+
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method: resty.MethodGet,
+		URL:    "https://www.tibia.com/charactertrade/?subtopic=currentcharactertrades" + query + "&currentpage=" + TibiaDataQueryEscapeString(page),
+	}
+
+	tibiaDataRequestHandler(
+		c,
+		tibiadataRequest,
+		func(BoxContentHTML string) (interface{}, error) {
+			return TibiaBazaarAuctionsImpl(filters, BoxContentHTML, tibiadataRequest.URL)
+		},
+		"TibiaBazaarAuctions")
+}
+
+// Bazaar history godoc
+// @Summary      List of finished char bazaar auctions
+// @Description  Show the finished character auctions of the char bazaar, optionally filtered
+// @Tags         bazaar
+// @Accept       json
+// @Produce      json
+// @Param        world      query string false "The world to filter on" extensions(x-example=Antica)
+// @Param        vocation   query string false "The vocation to filter on" extensions(x-example=knight)
+// @Param        level_from query int    false "The minimum level" extensions(x-example=100)
+// @Param        level_to   query int    false "The maximum level" extensions(x-example=500)
+// @Param        skill      query string false "The skill to filter on" extensions(x-example=magiclevel)
+// @Param        skill_from query int    false "The minimum skill level" extensions(x-example=50)
+// @Param        skill_to   query int    false "The maximum skill level" extensions(x-example=100)
+// @Param        page       query int    false "The current page" default(1) minimum(1) extensions(x-example=1)
+// @Success      200  {object}  BazaarHistoryResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Failure      503  {object}  Information
+// @Router       /v4/bazaar/history [get]
+func tibiaBazaarHistory(c *gin.Context) {
+	// Validate the filters
+	filters, query, err := tibiaBazaarFiltersFromQuery(c)
+	if err != nil {
+		TibiaDataErrorHandler(c, err, http.StatusBadRequest)
+		return
+	}
+
+	// Checking the page provided
+	page := c.DefaultQuery("page", "1")
+	if TibiaDataStringToInteger(page) < 1 {
+		TibiaDataErrorHandler(c, validation.ErrorPageInvalid, http.StatusBadRequest)
+		return
+	}
+
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method: resty.MethodGet,
+		URL:    "https://www.tibia.com/charactertrade/?subtopic=pastcharactertrades" + query + "&currentpage=" + TibiaDataQueryEscapeString(page),
+	}
+
+	tibiaDataRequestHandler(
+		c,
+		tibiadataRequest,
+		func(BoxContentHTML string) (interface{}, error) {
+			return TibiaBazaarHistoryImpl(filters, BoxContentHTML, tibiadataRequest.URL)
+		},
+		"TibiaBazaarHistory")
+}
+
 // BoostableBosses godoc
 // @Summary      List of boostable bosses
 // @Description  Show all boostable bosses listed
@@ -284,10 +530,14 @@ func tibiaBoostableBosses(c *gin.Context) {
 // Character godoc
 // @Summary      Show one character
 // @Description  Show all information about one character available
+// @Description  tibia.com shows deleted and banished characters like names that never existed. Only with the history enabled a recorded character that does not exist anymore returns 10011 (deleted or banished) and an unrecorded one 20001 (not found).
+// @Description  Without the history 10012 (unknown whether deleted or never existed) is returned instead.
 // @Tags         characters
 // @Accept       json
 // @Produce      json
-// @Param        name path string true "The character name" extensions(x-example=Trollefar)
+// @Param        name                path  string true  "The character name" extensions(x-example=Trollefar)
+// @Param        follow_former_names query bool   false "Whether a former name resolves to the renamed character" default(true)
+// @Param        former_owner        query bool   false "Whether a name taken by another character resolves to the recorded character that had it before (requires the history)" default(false)
 // @Success      200  {object}  CharacterResponse
 // @Failure      400  {object}  Information
 // @Failure      404  {object}  Information
@@ -296,6 +546,8 @@ func tibiaBoostableBosses(c *gin.Context) {
 func tibiaCharactersCharacter(c *gin.Context) {
 	// Getting params from URL
 	name := c.Param("name")
+	followFormerNames := c.DefaultQuery("follow_former_names", "true") != "false"
+	formerOwner := c.DefaultQuery("former_owner", "false") == "true"
 
 	// Validate the name
 	err := validation.IsCharacterNameValid(name)
@@ -304,10 +556,29 @@ func tibiaCharactersCharacter(c *gin.Context) {
 		return
 	}
 
+	// Looking up the character that had the name before, since tibia.com shows its new owner
+	lookupName := name
+	if formerOwner && followFormerNames {
+		if TibiaDataHistory == nil {
+			TibiaDataErrorHandler(c, validation.ErrorHistoryDisabled, http.StatusNotFound)
+			return
+		}
+
+		owner, err := tibiaDataHistoryFormerOwner(name)
+		if err != nil {
+			TibiaDataErrorHandler(c, err, http.StatusInternalServerError)
+			return
+		}
+		if owner != "" {
+			lookupName = owner
+		}
+	}
+
 	// Build the request structure
 	tibiadataRequest := TibiaDataRequestStruct{
-		Method: resty.MethodGet,
-		URL:    "https://www.tibia.com/community/?subtopic=characters&name=" + TibiaDataQueryEscapeString(name),
+		Method:   resty.MethodGet,
+		URL:      "https://www.tibia.com/community/?subtopic=characters&name=" + TibiaDataQueryEscapeString(lookupName),
+		Priority: TibiaDataPriorityHigh,
 	}
 
 	// Handle the request
@@ -315,11 +586,136 @@ func tibiaCharactersCharacter(c *gin.Context) {
 		c,
 		tibiadataRequest,
 		func(BoxContentHTML string) (interface{}, error) {
-			return TibiaCharactersCharacterImpl(BoxContentHTML, tibiadataRequest.URL)
+			characterJson, err := TibiaCharactersCharacterImpl(name, BoxContentHTML, tibiadataRequest.URL)
+			switch {
+			case err == validation.ErrorCharacterNotFound:
+				// telling deleted and banished characters apart depends on the history (disabled by default)
+				return CharacterResponse{}, tibiaDataHistoryCharacterNotFound(lookupName)
+			case err == nil && !followFormerNames && characterJson.Character.CharacterStatus.FormerName != "":
+				return CharacterResponse{}, validation.ErrorCharacterRenamed
+			}
+
+			return characterJson, err
 		},
 		"TibiaCharactersCharacter")
 }
 
+// Character progress godoc
+// @Summary      Progress of one character
+// @Description  Show the level and experience progress of one character per day or week, based on the recorded snapshots of the character and the experience highscores
+// @Description  Level-ups and level-downs are listed with the deaths that caused a level-down.
+// @Tags         characters
+// @Accept       json
+// @Produce      json
+// @Param        name   path  string true  "The character name" extensions(x-example=Trollefar)
+// @Param        period query string false "The period the progress is grouped by (day or week)" default(day)
+// @Param        from   query string false "The start of the time range (RFC3339 or YYYY-MM-DD, default 30 days or 12 weeks before to)" extensions(x-example=2026-01-01)
+// @Param        to     query string false "The end of the time range (RFC3339 or YYYY-MM-DD, default now)" extensions(x-example=2026-01-31)
+// @Success      200  {object}  CharacterProgressResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Router       /v4/character/{name}/progress [get]
+func tibiaCharactersProgress(c *gin.Context) {
+	// Getting params from URL
+	name := c.Param("name")
+	period := c.DefaultQuery("period", TibiaCharactersProgressDay)
+
+	// Validate the name
+	err := validation.IsCharacterNameValid(name)
+	if err != nil {
+		TibiaDataErrorHandler(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if period != TibiaCharactersProgressDay && period != TibiaCharactersProgressWeek {
+		TibiaDataErrorHandler(c, validation.ErrorHistoryPeriodInvalid, http.StatusBadRequest)
+		return
+	}
+
+	if TibiaDataHistory == nil {
+		TibiaDataErrorHandler(c, validation.ErrorHistoryDisabled, http.StatusNotFound)
+		return
+	}
+
+	to, err := tibiaDataTimeFromQuery(c, "to", time.Now())
+	if err != nil {
+		TibiaDataErrorHandler(c, err, http.StatusBadRequest)
+		return
+	}
+	defaultFrom := to.AddDate(0, 0, -30)
+	if period == TibiaCharactersProgressWeek {
+		defaultFrom = to.AddDate(0, 0, -12*7)
+	}
+	from, err := tibiaDataTimeFromQuery(c, "from", defaultFrom)
+	if err != nil {
+		TibiaDataErrorHandler(c, err, http.StatusBadRequest)
+		return
+	}
+	if to.Before(from) {
+		TibiaDataErrorHandler(c, validation.ErrorHistoryRangeInvalid, http.StatusBadRequest)
+		return
+	}
+
+	jsonData, err := TibiaCharactersProgressImpl(name, period, from, to)
+	if err != nil {
+		TibiaDataErrorHandler(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	TibiaDataAPIHandleResponse(c, "TibiaCharactersProgress", jsonData)
+}
+
+// Characters godoc
+// @Summary      Show multiple characters
+// @Description  Show all information about multiple characters at once
+// @Description  Characters that could not be shown contain an error instead of failing the whole request.
+// @Tags         characters
+// @Accept       json
+// @Produce      json
+// @Param        names body     CharactersRequest true "The character names"
+// @Success      200   {object} CharactersResponse
+// @Failure      400   {object} Information
+// @Failure      503   {object} Information
+// @Router       /v4/characters [post]
+func tibiaCharactersCharacters(c *gin.Context) {
+	// Getting names from the body
+	var request CharactersRequest
+	if err := c.ShouldBindJSON(&request); err != nil || len(request.Names) == 0 {
+		TibiaDataErrorHandler(c, validation.ErrorCharacterNamesEmpty, http.StatusBadRequest)
+		return
+	}
+
+	if len(request.Names) > TibiaDataCharactersMaxNames {
+		TibiaDataErrorHandler(c, validation.ErrorCharacterNamesTooMany, http.StatusBadRequest)
+		return
+	}
+
+	jsonData := TibiaCharactersCharactersImpl(request.Names, TibiaDataCharactersConcurrency, func(name string) (CharacterResponse, error) {
+		tibiadataRequest := TibiaDataRequestStruct{
+			Method:   resty.MethodGet,
+			URL:      "https://www.tibia.com/community/?subtopic=characters&name=" + TibiaDataQueryEscapeString(name),
+			Priority: TibiaDataPriorityHigh,
+		}
+
+		// every character is fetched like a request of the single character
+		result := tibiaDataRequestFetch(tibiadataRequest, func(BoxContentHTML string) (interface{}, error) {
+			characterJson, err := TibiaCharactersCharacterImpl(name, BoxContentHTML, tibiadataRequest.URL)
+			if err == validation.ErrorCharacterNotFound {
+				// the same as for the single character
+				return CharacterResponse{}, tibiaDataHistoryCharacterNotFound(name)
+			}
+			return characterJson, err
+		}, "TibiaCharactersCharacter", "")
+		if result.Err != nil {
+			return CharacterResponse{}, result.Err
+		}
+
+		return result.JSONData.(CharacterResponse), nil
+	})
+
+	TibiaDataAPIHandleResponse(c, "TibiaCharactersCharacters", jsonData)
+}
+
 // Creatures godoc
 // @Summary      List of creatures
 // @Description  Show all creatures listed
@@ -383,6 +779,88 @@ func tibiaCreaturesCreature(c *gin.Context) {
 		"TibiaCreaturesCreature")
 }
 
+// Events schedule godoc
+// @Summary      Show the event schedule of one month
+// @Description  Show all events of the event schedule of one month
+// @Tags         events
+// @Accept       json
+// @Produce      json
+// @Param        year  path int true "The year" extensions(x-example=2024)
+// @Param        month path int true "The month" minimum(1) maximum(12) extensions(x-example=3)
+// @Success      200  {object}  EventsScheduleResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Failure      503  {object}  Information
+// @Router       /v4/events/{year}/{month} [get]
+func tibiaEventsSchedule(c *gin.Context) {
+	// getting params from URL
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		TibiaDataErrorHandler(c, validation.ErrorStringCanNotBeConvertedToInt, http.StatusBadRequest)
+		return
+	}
+
+	month, err := strconv.Atoi(c.Param("month"))
+	if err != nil {
+		TibiaDataErrorHandler(c, validation.ErrorStringCanNotBeConvertedToInt, http.StatusBadRequest)
+		return
+	}
+
+	// Validate the month and year
+	if month < 1 || month > 12 || year < 1 {
+		TibiaDataErrorHandler(c, validation.ErrorEventScheduleDateInvalid, http.StatusBadRequest)
+		return
+	}
+
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method: resty.MethodGet,
+		URL:    "https://www.tibia.com/news/?subtopic=eventcalendar&calendarmonth=" + strconv.Itoa(month) + "&calendaryear=" + strconv.Itoa(year),
+	}
+
+	tibiaDataRequestHandler(
+		c,
+		tibiadataRequest,
+		func(BoxContentHTML string) (interface{}, error) {
+			return TibiaEventsScheduleImpl(year, month, BoxContentHTML, tibiadataRequest.URL)
+		},
+		"TibiaEventsSchedule")
+}
+
+// Active events godoc
+// @Summary      Show the active events
+// @Description  Show all events of the event schedule that are running right now
+// @Tags         events
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  EventsScheduleResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Failure      503  {object}  Information
+// @Router       /v4/events/active [get]
+func tibiaEventsActive(c *gin.Context) {
+	// the event schedule of tibia.com is in CET/CEST
+	now := time.Now().In(TibiaEventsScheduleLocation)
+
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method: resty.MethodGet,
+		URL:    "https://www.tibia.com/news/?subtopic=eventcalendar&calendarmonth=" + strconv.Itoa(int(now.Month())) + "&calendaryear=" + strconv.Itoa(now.Year()),
+	}
+
+	tibiaDataRequestHandler(
+		c,
+		tibiadataRequest,
+		func(BoxContentHTML string) (interface{}, error) {
+			data, err := TibiaEventsScheduleImpl(now.Year(), int(now.Month()), BoxContentHTML, tibiadataRequest.URL)
+			if err != nil {
+				return data, err
+			}
+
+			data.EventsSchedule.Events = TibiaEventsScheduleActive(data.EventsSchedule.Events, now)
+			return data, nil
+		},
+		"TibiaEventsActive")
+}
+
 // Fansites godoc
 // @Summary      Promoted and supported fansites
 // @Description  List of all promoted and supported fansites
@@ -422,6 +900,98 @@ func tibiaFansites(c *gin.Context) {
 // @Failure      503  {object}  Information
 // @Router       /v4/guild/{name} [get]
 func tibiaGuildsGuild(c *gin.Context) {
+	endpointRequest, err := tibiaGuildsGuildRequest(c.Param("name"))
+	if err != nil {
+		TibiaDataErrorHandler(c, err, 0)
+		return
+	}
+
+	tibiaDataRequestHandler(c, endpointRequest.Request, endpointRequest.Parser, endpointRequest.HandlerName)
+}
+
+// tibiaGuildsGuildRequest func - validates the name and returns the request of the guild
+func tibiaGuildsGuildRequest(guild string) (tibiaDataEndpointRequest, error) {
+	// Validate the name
+	err := validation.IsGuildNameValid(guild)
+	if err != nil {
+		return tibiaDataEndpointRequest{}, err
+	}
+
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method: resty.MethodGet,
+		URL:    "https://www.tibia.com/community/?subtopic=guilds&page=view&GuildName=" + TibiaDataQueryEscapeString(guild),
+	}
+
+	return tibiaDataEndpointRequest{
+		Request: tibiadataRequest,
+		Parser: func(BoxContentHTML string) (interface{}, error) {
+			return TibiaGuildsGuildImpl(guild, BoxContentHTML, tibiadataRequest.URL)
+		},
+		HandlerName: "TibiaGuildsGuild",
+	}, nil
+}
+
+// Guild changes godoc
+// @Summary      Show changes of one guild
+// @Description  Show the joins, leaves, rank, title, level and vocation changes of the members of one guild, computed from its recorded snapshots
+// @Tags         guilds
+// @Accept       json
+// @Produce      json
+// @Param        name  path  string true  "The name of guild" extensions(x-example=Elysium)
+// @Param        since query string false "The time changes are shown since (RFC3339 or YYYY-MM-DD, default 7 days ago)" extensions(x-example=2026-01-01)
+// @Success      200  {object}  GuildChangesResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Router       /v4/guild/{name}/changes [get]
+func tibiaGuildsGuildChanges(c *gin.Context) {
+	// getting params from URL
+	guild := c.Param("name")
+
+	// Validate the name
+	err := validation.IsGuildNameValid(guild)
+	if err != nil {
+		TibiaDataErrorHandler(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if TibiaDataHistory == nil {
+		TibiaDataErrorHandler(c, validation.ErrorHistoryDisabled, http.StatusNotFound)
+		return
+	}
+
+	now := time.Now()
+	since, err := tibiaDataTimeFromQuery(c, "since", now.AddDate(0, 0, -7))
+	if err != nil {
+		TibiaDataErrorHandler(c, err, http.StatusBadRequest)
+		return
+	}
+	if now.Before(since) {
+		TibiaDataErrorHandler(c, validation.ErrorHistoryRangeInvalid, http.StatusBadRequest)
+		return
+	}
+
+	jsonData, err := TibiaGuildsGuildChangesImpl(guild, since, now)
+	if err != nil {
+		TibiaDataErrorHandler(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	TibiaDataAPIHandleResponse(c, "TibiaGuildsGuildChanges", jsonData)
+}
+
+// Guild events godoc
+// @Summary      Show events of one guild
+// @Description  Show the event log of one guild
+// @Tags         guilds
+// @Accept       json
+// @Produce      json
+// @Param        name path string true "The name of guild" extensions(x-example=Elysium)
+// @Success      200  {object}  GuildEventsResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Failure      503  {object}  Information
+// @Router       /v4/guild/{name}/events [get]
+func tibiaGuildsGuildEvents(c *gin.Context) {
 	// getting params from URL
 	guild := c.Param("name")
 
@@ -434,16 +1004,53 @@ func tibiaGuildsGuild(c *gin.Context) {
 
 	tibiadataRequest := TibiaDataRequestStruct{
 		Method: resty.MethodGet,
-		URL:    "https://www.tibia.com/community/?subtopic=guilds&page=view&GuildName=" + TibiaDataQueryEscapeString(guild),
+		URL:    "https://www.tibia.com/community/?subtopic=guilds&page=guildevents&action=view&GuildName=" + TibiaDataQueryEscapeString(guild),
 	}
 
 	tibiaDataRequestHandler(
 		c,
 		tibiadataRequest,
 		func(BoxContentHTML string) (interface{}, error) {
-			return TibiaGuildsGuildImpl(guild, BoxContentHTML, tibiadataRequest.URL)
+			return TibiaGuildsGuildEventsImpl(guild, BoxContentHTML, tibiadataRequest.URL)
 		},
-		"TibiaGuildsGuild")
+		"TibiaGuildsGuildEvents")
+}
+
+// Guild wars godoc
+// @Summary      Show wars of one guild
+// @Description  Show the current war and the war history of one guild
+// @Tags         guilds
+// @Accept       json
+// @Produce      json
+// @Param        name path string true "The name of guild" extensions(x-example=Elysium)
+// @Success      200  {object}  GuildWarsResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Failure      503  {object}  Information
+// @Router       /v4/guild/{name}/wars [get]
+func tibiaGuildsGuildWars(c *gin.Context) {
+	// getting params from URL
+	guild := c.Param("name")
+
+	// Validate the name
+	err := validation.IsGuildNameValid(guild)
+	if err != nil {
+		TibiaDataErrorHandler(c, err, http.StatusBadRequest)
+		return
+	}
+
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method: resty.MethodGet,
+		URL:    "https://www.tibia.com/community/?subtopic=guilds&page=guildwars&action=view&GuildName=" + TibiaDataQueryEscapeString(guild),
+	}
+
+	tibiaDataRequestHandler(
+		c,
+		tibiadataRequest,
+		func(BoxContentHTML string) (interface{}, error) {
+			return TibiaGuildsGuildWarsImpl(guild, BoxContentHTML, tibiadataRequest.URL)
+		},
+		"TibiaGuildsGuildWars")
 }
 
 // Guilds godoc
@@ -459,19 +1066,25 @@ func tibiaGuildsGuild(c *gin.Context) {
 // @Failure      503  {object}  Information
 // @Router       /v4/guilds/{world} [get]
 func tibiaGuildsOverview(c *gin.Context) {
-	// getting params from URL
-	world := c.Param("world")
+	endpointRequest, err := tibiaGuildsOverviewRequest(c.Param("world"))
+	if err != nil {
+		TibiaDataErrorHandler(c, err, 0)
+		return
+	}
 
+	tibiaDataRequestHandler(c, endpointRequest.Request, endpointRequest.Parser, endpointRequest.HandlerName)
+}
+
+// tibiaGuildsOverviewRequest func - validates the world and returns the request of its guilds
+func tibiaGuildsOverviewRequest(world string) (tibiaDataEndpointRequest, error) {
 	// Check if world exists
 	exists, err := validation.WorldExists(world)
 	if err != nil {
-		TibiaDataErrorHandler(c, err, 0)
-		return
+		return tibiaDataEndpointRequest{}, err
 	}
 
 	if !exists {
-		TibiaDataErrorHandler(c, validation.ErrorWorldDoesNotExist, http.StatusBadRequest)
-		return
+		return tibiaDataEndpointRequest{}, validation.ErrorWorldDoesNotExist
 	}
 
 	// Adding fix for First letter to be upper and rest lower
@@ -482,13 +1095,13 @@ func tibiaGuildsOverview(c *gin.Context) {
 		URL:    "https://www.tibia.com/community/?subtopic=guilds&world=" + TibiaDataQueryEscapeString(world),
 	}
 
-	tibiaDataRequestHandler(
-		c,
-		tibiadataRequest,
-		func(BoxContentHTML string) (interface{}, error) {
+	return tibiaDataEndpointRequest{
+		Request: tibiadataRequest,
+		Parser: func(BoxContentHTML string) (interface{}, error) {
 			return TibiaGuildsOverviewImpl(world, BoxContentHTML, tibiadataRequest.URL)
 		},
-		"TibiaGuildsOverview")
+		HandlerName: "TibiaGuildsOverview",
+	}, nil
 }
 
 // Highscores godoc
@@ -508,17 +1121,21 @@ func tibiaGuildsOverview(c *gin.Context) {
 // @Failure      503  {object}  Information
 // @Router       /v4/highscores/{world}/{category}/{vocation}/{page} [get]
 func tibiaHighscores(c *gin.Context) {
-	// getting params from URL
-	world := c.Param("world")
-	category := c.Param("category")
-	vocation := c.Param("vocation")
-	page := c.Param("page")
+	endpointRequest, err := tibiaHighscoresRequest(c.Param("world"), c.Param("category"), c.Param("vocation"), c.Param("page"))
+	if err != nil {
+		TibiaDataErrorHandler(c, err, 0)
+		return
+	}
 
+	tibiaDataRequestHandler(c, endpointRequest.Request, endpointRequest.Parser, endpointRequest.HandlerName)
+}
+
+// tibiaHighscoresRequest func - validates the params and returns the request of the highscores page
+func tibiaHighscoresRequest(world, category, vocation, page string) (tibiaDataEndpointRequest, error) {
 	// Check if vocation is valid
 	err := validation.IsVocationValid(vocation)
 	if err != nil {
-		TibiaDataErrorHandler(c, err, http.StatusBadRequest)
-		return
+		return tibiaDataEndpointRequest{}, err
 	}
 
 	// Adding fix for First letter to be upper and rest lower
@@ -532,21 +1149,18 @@ func tibiaHighscores(c *gin.Context) {
 		// Check if world exists
 		exists, err := validation.WorldExists(world)
 		if err != nil {
-			TibiaDataErrorHandler(c, err, 0)
-			return
+			return tibiaDataEndpointRequest{}, err
 		}
 
 		if !exists {
-			TibiaDataErrorHandler(c, validation.ErrorWorldDoesNotExist, http.StatusBadRequest)
-			return
+			return tibiaDataEndpointRequest{}, validation.ErrorWorldDoesNotExist
 		}
 	}
 
 	if category != "" {
 		err = validation.IsHighscoreCategoryValid(category)
 		if err != nil {
-			TibiaDataErrorHandler(c, validation.ErrorHighscoreCategoryDoesNotExist, http.StatusBadRequest)
-			return
+			return tibiaDataEndpointRequest{}, validation.ErrorHighscoreCategoryDoesNotExist
 		}
 	}
 
@@ -557,8 +1171,7 @@ func tibiaHighscores(c *gin.Context) {
 
 	// Check if restriction mode is enabled
 	if TibiaDataRestrictionMode && vocationName != "all" {
-		TibiaDataErrorHandler(c, validation.ErrorRestrictionMode, http.StatusBadRequest)
-		return
+		return tibiaDataEndpointRequest{}, validation.ErrorRestrictionMode
 	}
 
 	// checking the page provided
@@ -566,22 +1179,178 @@ func tibiaHighscores(c *gin.Context) {
 		page = "1"
 	}
 	if TibiaDataStringToInteger(page) < 1 {
-		TibiaDataErrorHandler(c, validation.ErrorHighscorePageInvalid, http.StatusBadRequest)
-		return
+		return tibiaDataEndpointRequest{}, validation.ErrorHighscorePageInvalid
 	}
 
 	tibiadataRequest := TibiaDataRequestStruct{
-		Method: resty.MethodGet,
-		URL:    "https://www.tibia.com/community/?subtopic=highscores&world=" + TibiaDataQueryEscapeString(world) + "&category=" + strconv.Itoa(int(highscoreCategory)) + "&profession=" + TibiaDataQueryEscapeString(vocationid) + "&currentpage=" + TibiaDataQueryEscapeString(page),
+		Method:   resty.MethodGet,
+		URL:      "https://www.tibia.com/community/?subtopic=highscores&world=" + TibiaDataQueryEscapeString(world) + "&category=" + strconv.Itoa(int(highscoreCategory)) + "&profession=" + TibiaDataQueryEscapeString(vocationid) + "&currentpage=" + TibiaDataQueryEscapeString(page),
+		Priority: TibiaDataPriorityLow,
 	}
 
-	tibiaDataRequestHandler(
-		c,
-		tibiadataRequest,
-		func(BoxContentHTML string) (interface{}, error) {
+	return tibiaDataEndpointRequest{
+		Request: tibiadataRequest,
+		Parser: func(BoxContentHTML string) (interface{}, error) {
 			return TibiaHighscoresImpl(world, highscoreCategory, vocationName, TibiaDataStringToInteger(page), BoxContentHTML, tibiadataRequest.URL)
 		},
-		"TibiaHighscores")
+		HandlerName: "TibiaHighscores",
+	}, nil
+}
+
+// Guild history godoc
+// @Summary      History of one guild
+// @Description  Show the recorded snapshots of one guild within a time range
+// @Tags         history
+// @Accept       json
+// @Produce      json
+// @Param        name  path  string true  "The name of guild" extensions(x-example=Elysium)
+// @Param        from  query string false "The start of the time range (RFC3339 or YYYY-MM-DD)" extensions(x-example=2026-01-01)
+// @Param        to    query string false "The end of the time range (RFC3339 or YYYY-MM-DD, default now)" extensions(x-example=2026-01-31)
+// @Param        limit query int    false "The number of most recent snapshots returned" default(100) minimum(1) maximum(1000)
+// @Success      200  {object}  HistoryResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Router       /v4/history/guild/{name} [get]
+func tibiaHistoryGuild(c *gin.Context) {
+	// getting params from URL
+	guild := c.Param("name")
+
+	// Validate the name
+	err := validation.IsGuildNameValid(guild)
+	if err != nil {
+		TibiaDataErrorHandler(c, err, http.StatusBadRequest)
+		return
+	}
+
+	tibiaDataHistoryHandler(c, "guild", guild)
+}
+
+// Highscores history godoc
+// @Summary      History of one highscores page
+// @Description  Show the recorded snapshots of one highscores page within a time range
+// @Tags         history
+// @Accept       json
+// @Produce      json
+// @Param        world    path  string true  "The world" default(all) extensions(x-example=Antica)
+// @Param        category path  string true  "The category" default(experience) Enums(achievements, axefighting, bosspoints, bountypoints, charmpoints, clubfighting, distancefighting, dromescore, experience, fishing, fistfighting, goshnarstaint, loyaltypoints, magiclevel, shielding, swordfighting, weeklytasks) extensions(x-example=experience)
+// @Param        vocation path  string true  "The vocation" default(all) Enums(all, knights, paladins, sorcerers, druids, monks) extensions(x-example=all)
+// @Param        page     path  int    true  "The current page" default(1) minimum(1) extensions(x-example=1)
+// @Param        from     query string false "The start of the time range (RFC3339 or YYYY-MM-DD)" extensions(x-example=2026-01-01)
+// @Param        to       query string false "The end of the time range (RFC3339 or YYYY-MM-DD, default now)" extensions(x-example=2026-01-31)
+// @Param        limit    query int    false "The number of most recent snapshots returned" default(100) minimum(1) maximum(1000)
+// @Success      200  {object}  HistoryResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Router       /v4/history/highscores/{world}/{category}/{vocation}/{page} [get]
+func tibiaHistoryHighscores(c *gin.Context) {
+	// getting params from URL
+	world := c.Param("world")
+	category := c.Param("category")
+	vocation := c.Param("vocation")
+	page := c.Param("page")
+
+	// Check if vocation is valid
+	err := validation.IsVocationValid(vocation)
+	if err != nil {
+		TibiaDataErrorHandler(c, err, http.StatusBadRequest)
+		return
+	}
+
+	// Adding fix for First letter to be upper and rest lower
+	if strings.EqualFold(world, "all") {
+		world = ""
+	} else {
+		world = TibiaDataStringWorldFormatToTitle(world)
+
+		// Check if world exists
+		exists, err := validation.WorldExists(world)
+		if err != nil {
+			TibiaDataErrorHandler(c, err, 0)
+			return
+		}
+
+		if !exists {
+			TibiaDataErrorHandler(c, validation.ErrorWorldDoesNotExist, http.StatusBadRequest)
+			return
+		}
+	}
+
+	err = validation.IsHighscoreCategoryValid(category)
+	if err != nil {
+		TibiaDataErrorHandler(c, validation.ErrorHighscoreCategoryDoesNotExist, http.StatusBadRequest)
+		return
+	}
+	categoryName, _ := validation.HighscoreCategoryFromString(category).String()
+
+	// Sanitize of vocation input
+	vocationName, _ := TibiaDataVocationValidator(vocation)
+
+	// checking the page provided
+	if TibiaDataStringToInteger(page) < 1 {
+		TibiaDataErrorHandler(c, validation.ErrorHighscorePageInvalid, http.StatusBadRequest)
+		return
+	}
+
+	tibiaDataHistoryHandler(c, "highscores", tibiaDataHistoryHighscoresKey(world, categoryName, vocationName, TibiaDataStringToInteger(page)))
+}
+
+// Killstatistics history godoc
+// @Summary      History of the killstatistics of a world
+// @Description  Show the recorded snapshots of the killstatistics of a world within a time range
+// @Tags         history
+// @Accept       json
+// @Produce      json
+// @Param        world path  string true  "The world" extensions(x-example=Antica)
+// @Param        from  query string false "The start of the time range (RFC3339 or YYYY-MM-DD)" extensions(x-example=2026-01-01)
+// @Param        to    query string false "The end of the time range (RFC3339 or YYYY-MM-DD, default now)" extensions(x-example=2026-01-31)
+// @Param        limit query int    false "The number of most recent snapshots returned" default(100) minimum(1) maximum(1000)
+// @Success      200  {object}  HistoryResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Router       /v4/history/killstatistics/{world} [get]
+func tibiaHistoryKillstatistics(c *gin.Context) {
+	tibiaHistoryWorldParam(c, "world", "killstatistics")
+}
+
+// World history godoc
+// @Summary      History of one world
+// @Description  Show the recorded snapshots of one world within a time range
+// @Tags         history
+// @Accept       json
+// @Produce      json
+// @Param        name  path  string true  "The name of world" extensions(x-example=Antica)
+// @Param        from  query string false "The start of the time range (RFC3339 or YYYY-MM-DD)" extensions(x-example=2026-01-01)
+// @Param        to    query string false "The end of the time range (RFC3339 or YYYY-MM-DD, default now)" extensions(x-example=2026-01-31)
+// @Param        limit query int    false "The number of most recent snapshots returned" default(100) minimum(1) maximum(1000)
+// @Success      200  {object}  HistoryResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Router       /v4/history/world/{name} [get]
+func tibiaHistoryWorld(c *gin.Context) {
+	tibiaHistoryWorldParam(c, "name", "world")
+}
+
+// tibiaHistoryWorldParam func - validates the world of the param and returns the history of the kind
+func tibiaHistoryWorldParam(c *gin.Context, param, kind string) {
+	// getting params from URL
+	world := c.Param(param)
+
+	// Adding fix for First letter to be upper and rest lower
+	world = TibiaDataStringWorldFormatToTitle(world)
+
+	// Check if world exists
+	exists, err := validation.WorldExists(world)
+	if err != nil {
+		TibiaDataErrorHandler(c, err, 0)
+		return
+	}
+
+	if !exists {
+		TibiaDataErrorHandler(c, validation.ErrorWorldDoesNotExist, http.StatusBadRequest)
+		return
+	}
+
+	tibiaDataHistoryHandler(c, kind, world)
 }
 
 // House godoc
@@ -725,8 +1494,63 @@ func tibiaHousesOverview(c *gin.Context) {
 // @Failure      503  {object}  Information
 // @Router       /v4/killstatistics/{world} [get]
 func tibiaKillstatistics(c *gin.Context) {
+	endpointRequest, err := tibiaKillstatisticsRequest(c.Param("world"))
+	if err != nil {
+		TibiaDataErrorHandler(c, err, 0)
+		return
+	}
+
+	tibiaDataRequestHandler(c, endpointRequest.Request, endpointRequest.Parser, endpointRequest.HandlerName)
+}
+
+// tibiaKillstatisticsRequest func - validates the world and returns the request of its kill statistics
+func tibiaKillstatisticsRequest(world string) (tibiaDataEndpointRequest, error) {
+	// Adding fix for First letter to be upper and rest lower
+	world = TibiaDataStringWorldFormatToTitle(world)
+
+	// Check if world exists
+	exists, err := validation.WorldExists(world)
+	if err != nil {
+		return tibiaDataEndpointRequest{}, err
+	}
+
+	if !exists {
+		return tibiaDataEndpointRequest{}, validation.ErrorWorldDoesNotExist
+	}
+
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method: resty.MethodGet,
+		URL:    "https://www.tibia.com/community/?subtopic=killstatistics&world=" + TibiaDataQueryEscapeString(world),
+	}
+
+	return tibiaDataEndpointRequest{
+		Request: tibiadataRequest,
+		Parser: func(BoxContentHTML string) (interface{}, error) {
+			return TibiaKillstatisticsImpl(world, BoxContentHTML, tibiadataRequest.URL)
+		},
+		HandlerName: "TibiaKillstatistics",
+	}, nil
+}
+
+// Leaderboards godoc
+// @Summary      Leaderboards of a world
+// @Description  Show the Tibiadrome leaderboard of a world for the current or a given rotation
+// @Tags         leaderboards
+// @Accept       json
+// @Produce      json
+// @Param        world    path  string true  "The world" extensions(x-example=Antica)
+// @Param        rotation query int    false "The rotation, defaults to the current rotation" extensions(x-example=32)
+// @Param        page     query int    false "The current page" default(1) minimum(1) extensions(x-example=1)
+// @Success      200  {object}  LeaderboardResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Failure      503  {object}  Information
+// @Router       /v4/leaderboards/{world} [get]
+func tibiaLeaderboards(c *gin.Context) {
 	// getting params from URL
 	world := c.Param("world")
+	rotation := c.Query("rotation")
+	page := c.DefaultQuery("page", "1")
 
 	// Adding fix for First letter to be upper and rest lower
 	world = TibiaDataStringWorldFormatToTitle(world)
@@ -743,18 +1567,80 @@ func tibiaKillstatistics(c *gin.Context) {
 		return
 	}
 
+	// Checking the rotation provided
+	if rotation != "" {
+		if _, err := strconv.Atoi(rotation); err != nil {
+			TibiaDataErrorHandler(c, validation.ErrorStringCanNotBeConvertedToInt, http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Checking the page provided
+	if TibiaDataStringToInteger(page) < 1 {
+		TibiaDataErrorHandler(c, validation.ErrorPageInvalid, http.StatusBadRequest)
+		return
+	}
+
 	tibiadataRequest := TibiaDataRequestStruct{
-		Method: resty.MethodGet,
-		URL:    "https://www.tibia.com/community/?subtopic=killstatistics&world=" + TibiaDataQueryEscapeString(world),
+		Method:   resty.MethodGet,
+		URL:      "https://www.tibia.com/community/?subtopic=leaderboards&world=" + TibiaDataQueryEscapeString(world) + "&rotation=" + TibiaDataQueryEscapeString(rotation) + "&currentpage=" + TibiaDataQueryEscapeString(page),
+		Priority: TibiaDataPriorityLow,
 	}
 
 	tibiaDataRequestHandler(
 		c,
 		tibiadataRequest,
 		func(BoxContentHTML string) (interface{}, error) {
-			return TibiaKillstatisticsImpl(world, BoxContentHTML, tibiadataRequest.URL)
+			return TibiaLeaderboardsImpl(world, BoxContentHTML, tibiadataRequest.URL)
 		},
-		"TibiaKillstatistics")
+		"TibiaLeaderboards")
+}
+
+// Experience table godoc
+// @Summary      Show the experience table
+// @Description  Show the experience points needed for each level
+// @Tags         library
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ExperienceTableResponse
+// @Failure      400  {object}  Information
+// @Router       /v4/library/experience [get]
+func tibiaLibraryExperienceTable(c *gin.Context) {
+	TibiaDataAPIHandleResponse(c, "TibiaLibraryExperienceTable", TibiaLibraryExperienceTableImpl())
+}
+
+// Experience range godoc
+// @Summary      Show the experience needed between two levels
+// @Description  Show the experience points needed to advance from one level to another
+// @Tags         library
+// @Accept       json
+// @Produce      json
+// @Param        from path int true "The level to start from" minimum(1) extensions(x-example=100)
+// @Param        to   path int true "The level to reach" minimum(1) extensions(x-example=200)
+// @Success      200  {object}  ExperienceRangeResponse
+// @Failure      400  {object}  Information
+// @Router       /v4/library/experience/{from}/{to} [get]
+func tibiaLibraryExperienceRange(c *gin.Context) {
+	// getting params from URL
+	from, err := strconv.Atoi(c.Param("from"))
+	if err != nil {
+		TibiaDataErrorHandler(c, validation.ErrorStringCanNotBeConvertedToInt, http.StatusBadRequest)
+		return
+	}
+
+	to, err := strconv.Atoi(c.Param("to"))
+	if err != nil {
+		TibiaDataErrorHandler(c, validation.ErrorStringCanNotBeConvertedToInt, http.StatusBadRequest)
+		return
+	}
+
+	// Validate the level range
+	if from < 1 || from > to || to > ExperienceMaxLevel {
+		TibiaDataErrorHandler(c, validation.ErrorLevelRangeInvalid, http.StatusBadRequest)
+		return
+	}
+
+	TibiaDataAPIHandleResponse(c, "TibiaLibraryExperienceRange", TibiaLibraryExperienceRangeImpl(from, to))
 }
 
 // News archive godoc
@@ -929,6 +1815,76 @@ func tibiaNews(c *gin.Context) {
 		"TibiaNews")
 }
 
+// Poll godoc
+// @Summary      Show one poll
+// @Description  Show the question, options and results of one poll
+// @Tags         polls
+// @Accept       json
+// @Produce      json
+// @Param        id  path int true "The ID of the poll" minimum(1) extensions(x-example=3412)
+// @Success      200  {object}  PollResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Failure      503  {object}  Information
+// @Router       /v4/poll/{id} [get]
+func tibiaPoll(c *gin.Context) {
+	// getting params from URL
+	pollIDStr := c.Param("id")
+
+	// convert param to int
+	pollID, err := strconv.Atoi(pollIDStr)
+	if err != nil {
+		TibiaDataErrorHandler(c, validation.ErrorStringCanNotBeConvertedToInt, http.StatusBadRequest)
+		return
+	}
+
+	// checking the PollID provided
+	err = validation.IsPollIDValid(pollID)
+	if err != nil {
+		TibiaDataErrorHandler(c, err, http.StatusBadRequest)
+		return
+	}
+
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method: resty.MethodGet,
+		URL:    "https://www.tibia.com/community/?subtopic=polls&id=" + pollIDStr,
+	}
+
+	tibiaDataRequestHandler(
+		c,
+		tibiadataRequest,
+		func(BoxContentHTML string) (interface{}, error) {
+			return TibiaPollImpl(pollID, tibiadataRequest.URL, BoxContentHTML)
+		},
+		"TibiaPoll")
+}
+
+// Polls godoc
+// @Summary      List all polls
+// @Description  Show all current and past polls
+// @Tags         polls
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  PollsListResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Failure      503  {object}  Information
+// @Router       /v4/polls [get]
+func tibiaPollslist(c *gin.Context) {
+	tibiadataRequest := TibiaDataRequestStruct{
+		Method: resty.MethodGet,
+		URL:    "https://www.tibia.com/community/?subtopic=polls",
+	}
+
+	tibiaDataRequestHandler(
+		c,
+		tibiadataRequest,
+		func(BoxContentHTML string) (interface{}, error) {
+			return TibiaPollslistImpl(BoxContentHTML, tibiadataRequest.URL)
+		},
+		"TibiaPollslist")
+}
+
 // Spells godoc
 // @Summary      List all spells
 // @Description  Show all spells
@@ -1025,18 +1981,24 @@ func tibiaSpellsSpell(c *gin.Context) {
 // @Failure      503  {object}  Information
 // @Router       /v4/worlds [get]
 func tibiaWorldsOverview(c *gin.Context) {
+	endpointRequest := tibiaWorldsOverviewRequest()
+	tibiaDataRequestHandler(c, endpointRequest.Request, endpointRequest.Parser, endpointRequest.HandlerName)
+}
+
+// tibiaWorldsOverviewRequest func - returns the request of the worlds
+func tibiaWorldsOverviewRequest() tibiaDataEndpointRequest {
 	tibiadataRequest := TibiaDataRequestStruct{
 		Method: resty.MethodGet,
 		URL:    "https://www.tibia.com/community/?subtopic=worlds",
 	}
 
-	tibiaDataRequestHandler(
-		c,
-		tibiadataRequest,
-		func(BoxContentHTML string) (interface{}, error) {
+	return tibiaDataEndpointRequest{
+		Request: tibiadataRequest,
+		Parser: func(BoxContentHTML string) (interface{}, error) {
 			return TibiaWorldsOverviewImpl(BoxContentHTML, tibiadataRequest.URL)
 		},
-		"TibiaWorldsOverview")
+		HandlerName: "TibiaWorldsOverview",
+	}
 }
 
 // World godoc
@@ -1052,22 +2014,28 @@ func tibiaWorldsOverview(c *gin.Context) {
 // @Failure      503  {object}  Information
 // @Router       /v4/world/{name} [get]
 func tibiaWorldsWorld(c *gin.Context) {
-	// getting params from URL
-	world := c.Param("name")
+	endpointRequest, err := tibiaWorldsWorldRequest(c.Param("name"))
+	if err != nil {
+		TibiaDataErrorHandler(c, err, 0)
+		return
+	}
+
+	tibiaDataRequestHandler(c, endpointRequest.Request, endpointRequest.Parser, endpointRequest.HandlerName)
+}
 
+// tibiaWorldsWorldRequest func - validates the world and returns the request of the world
+func tibiaWorldsWorldRequest(world string) (tibiaDataEndpointRequest, error) {
 	// Adding fix for First letter to be upper and rest lower
 	world = TibiaDataStringWorldFormatToTitle(world)
 
 	// Check if world exists
 	exists, err := validation.WorldExists(world)
 	if err != nil {
-		TibiaDataErrorHandler(c, err, 0)
-		return
+		return tibiaDataEndpointRequest{}, err
 	}
 
 	if !exists {
-		TibiaDataErrorHandler(c, validation.ErrorWorldDoesNotExist, http.StatusBadRequest)
-		return
+		return tibiaDataEndpointRequest{}, validation.ErrorWorldDoesNotExist
 	}
 
 	tibiadataRequest := TibiaDataRequestStruct{
@@ -1075,13 +2043,83 @@ func tibiaWorldsWorld(c *gin.Context) {
 		URL:    "https://www.tibia.com/community/?subtopic=worlds&world=" + TibiaDataQueryEscapeString(world),
 	}
 
-	tibiaDataRequestHandler(
-		c,
-		tibiadataRequest,
-		func(BoxContentHTML string) (interface{}, error) {
+	return tibiaDataEndpointRequest{
+		Request: tibiadataRequest,
+		Parser: func(BoxContentHTML string) (interface{}, error) {
 			return TibiaWorldsWorldImpl(world, BoxContentHTML, tibiadataRequest.URL)
 		},
-		"TibiaWorldsWorld")
+		HandlerName: "TibiaWorldsWorld",
+	}, nil
+}
+
+// Online godoc
+// @Summary      List of online characters of all worlds
+// @Description  Show all characters currently online on any world, optionally filtered
+// @Tags         worlds
+// @Accept       json
+// @Produce      json
+// @Param        vocation   query string false "The vocation to filter on" extensions(x-example=knight)
+// @Param        level_from query int    false "The minimum level" extensions(x-example=100)
+// @Param        level_to   query int    false "The maximum level" extensions(x-example=500)
+// @Success      200  {object}  OnlineResponse
+// @Failure      400  {object}  Information
+// @Failure      404  {object}  Information
+// @Failure      503  {object}  Information
+// @Router       /v4/online [get]
+func tibiaWorldsOnline(c *gin.Context) {
+	// Check if vocation is valid
+	vocation := c.DefaultQuery("vocation", TibiaDataDefaultVoc)
+	err := validation.IsVocationValid(vocation)
+	if err != nil {
+		TibiaDataErrorHandler(c, err, http.StatusBadRequest)
+		return
+	}
+
+	// Sanitize of vocation input
+	vocationName, _ := TibiaDataVocationValidator(vocation)
+	filters := OnlineFilters{
+		Vocation: vocationName,
+	}
+
+	// Check the level range
+	filters.LevelFrom, filters.LevelTo, err = tibiaDataRangeFromQuery(c, "level_from", "level_to")
+	if err != nil {
+		if err != validation.ErrorStringCanNotBeConvertedToInt {
+			err = validation.ErrorLevelRangeInvalid
+		}
+		TibiaDataErrorHandler(c, err, http.StatusBadRequest)
+		return
+	}
+
+	worlds, err := validation.GetWorlds()
+	if err != nil {
+		TibiaDataErrorHandler(c, err, 0)
+		return
+	}
+
+	jsonData, err := TibiaWorldsOnlineImpl(worlds, filters, TibiaDataOnlineConcurrency, func(world string) (WorldResponse, error) {
+		tibiadataRequest := TibiaDataRequestStruct{
+			Method:   resty.MethodGet,
+			URL:      "https://www.tibia.com/community/?subtopic=worlds&world=" + TibiaDataQueryEscapeString(world),
+			Priority: TibiaDataPriorityLow,
+		}
+
+		// every world is fetched like a request of the single world
+		result := tibiaDataRequestFetch(tibiadataRequest, func(BoxContentHTML string) (interface{}, error) {
+			return TibiaWorldsWorldImpl(world, BoxContentHTML, tibiadataRequest.URL)
+		}, "TibiaWorldsWorld", "")
+		if result.Err != nil {
+			return WorldResponse{}, result.Err
+		}
+
+		return result.JSONData.(WorldResponse), nil
+	})
+	if err != nil {
+		TibiaDataErrorHandler(c, err, http.StatusBadGateway)
+		return
+	}
+
+	TibiaDataAPIHandleResponse(c, "TibiaWorldsOnline", jsonData)
 }
 
 func TibiaDataErrorHandler(c *gin.Context, err error, httpCode int) {
@@ -1089,6 +2127,14 @@ func TibiaDataErrorHandler(c *gin.Context, err error, httpCode int) {
 		panic(errors.New("TibiaDataErrorHandler called with nil err"))
 	}
 
+	// tibia.com is not requested right now, so the client should try again later
+	var unavailableErr TibiaDataUnavailableError
+	if errors.As(err, &unavailableErr) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(unavailableErr.RetryAfter.Seconds()))))
+		err = unavailableErr.Err
+		httpCode = http.StatusServiceUnavailable
+	}
+
 	info := Information{
 		APIDetails: TibiaDataAPIDetails,
 		Timestamp:  TibiaDataDatetime(""),
@@ -1134,21 +2180,225 @@ func TibiaDataErrorHandler(c *gin.Context, err error, httpCode int) {
 }
 
 func tibiaDataRequestHandler(c *gin.Context, tibiaDataRequest TibiaDataRequestStruct, requestHandler func(string) (interface{}, error), handlerName string) {
+	// the parser may depend on the query of the request as well
+	query := ""
+	if c.Request != nil {
+		query = c.Request.URL.RequestURI()
+	}
+
+	result := tibiaDataRequestFetch(tibiaDataRequest, requestHandler, handlerName, query)
+	if result.Err != nil {
+		TibiaDataErrorHandler(c, result.Err, result.HTTPCode)
+		return
+	}
+
+	// return jsonData
+	tibiaDataCacheHeaders(c, result.Entry, time.Now())
+	TibiaDataAPIHandleResponse(c, handlerName, result.JSONData)
+}
+
+// tibiaDataRequestFetch func - returns the parsed content of tibia.com, using the cache, the stale content and the history like single requests
+// query must contain everything besides the request to tibia.com the parser depends on
+// a refresh always requests tibia.com, its content is still cached and recorded
+func tibiaDataRequestFetch(tibiaDataRequest TibiaDataRequestStruct, requestHandler func(string) (interface{}, error), handlerName, query string) tibiaDataCoalescingResult {
+	cacheKey := tibiaDataCacheKey(tibiaDataRequest)
+
+	coalescingKey := handlerName + " " + cacheKey
+	if query != "" {
+		coalescingKey += " " + query
+	}
+
+	// a refresh does not share the fetch of a request that may be served from the cache
+	if tibiaDataRequest.Refresh {
+		result, _ := TibiaDataCoalescing.Do("refresh "+coalescingKey, func() tibiaDataCoalescingResult {
+			return tibiaDataRequestRefresh(tibiaDataRequest, requestHandler, handlerName, cacheKey, time.Now())
+		})
+		return result
+	}
+
+	// identical requests in flight share one fetch and one parse
+	result, _ := TibiaDataCoalescing.Do(coalescingKey, func() tibiaDataCoalescingResult {
+		now := time.Now()
+
+		// using the content of tibia.com from the cache if available
+		entry, cached, err := TibiaDataCache.Get(cacheKey, now)
+		if err != nil {
+			// an unavailable cache should not make the api unavailable
+			log.Printf("[warning] %s cache get failed: %s", handlerName, err)
+		}
+
+		if cached && now.Before(entry.Expires) {
+			jsonData, err := requestHandler(entry.Content)
+			return tibiaDataCoalescingResult{Entry: entry, JSONData: jsonData, Err: err}
+		}
+
+		refresh := func() tibiaDataCoalescingResult {
+			return tibiaDataRequestRefresh(tibiaDataRequest, requestHandler, handlerName, cacheKey, time.Now())
+		}
+
+		// expired content can be served while tibia.com is failing
+		if cached && now.Before(entry.StaleUntil) {
+			if TibiaDataStale.Failing(cacheKey) {
+				// tibia.com is not waited for, it is refreshed in the background until it recovers
+				TibiaDataStale.Refresh(cacheKey, now, func() error { return refresh().Err })
+				return tibiaDataStaleResult(entry, requestHandler)
+			}
+
+			result := refresh()
+			if result.Err != nil && result.HTTPCode == http.StatusBadGateway {
+				// requests rejected by the rate limiter or the circuit breaker never reached tibia.com,
+				// so only the other failures make it wait for the retry interval
+				var unavailableErr TibiaDataUnavailableError
+				if !errors.As(result.Err, &unavailableErr) {
+					TibiaDataStale.Failed(cacheKey, now)
+				}
+				return tibiaDataStaleResult(entry, requestHandler)
+			}
+
+			TibiaDataStale.Recovered(cacheKey)
+			return result
+		}
+
+		return refresh()
+	})
+
+	return result
+}
+
+// tibiaDataRequestRefresh func - fetches the content of tibia.com, parses it and stores it in the cache
+func tibiaDataRequestRefresh(tibiaDataRequest TibiaDataRequestStruct, requestHandler func(string) (interface{}, error), handlerName, cacheKey string, now time.Time) tibiaDataCoalescingResult {
 	BoxContentHTML, err := TibiaDataHTMLDataCollector(tibiaDataRequest)
 	// return error (e.g. for maintenance mode)
 	if err != nil {
-		TibiaDataErrorHandler(c, err, http.StatusBadGateway)
+		return tibiaDataCoalescingResult{Err: err, HTTPCode: http.StatusBadGateway}
+	}
+
+	entry := TibiaDataCacheEntry{
+		Content: BoxContentHTML,
+		Created: now,
+	}
+
+	jsonData, err := requestHandler(entry.Content)
+	if err != nil {
+		return tibiaDataCoalescingResult{Err: err}
+	}
+
+	// recording the parsed content if the history is enabled
+	TibiaDataHistory.Record(handlerName, jsonData, now)
+
+	// only content that could be parsed is cached
+	if ttl := tibiaDataCacheTTL(handlerName, jsonData); ttl > 0 {
+		entry.Expires = now.Add(ttl)
+		entry.StaleUntil = entry.Expires.Add(tibiaDataCacheMaxStale(handlerName))
+		if err := TibiaDataCache.Set(cacheKey, entry); err != nil {
+			log.Printf("[warning] %s cache set failed: %s", handlerName, err)
+		}
+	}
+
+	return tibiaDataCoalescingResult{
+		Entry:    entry,
+		JSONData: jsonData,
+	}
+}
+
+// tibiaDataStaleResult func - parses expired content and marks the response as stale
+func tibiaDataStaleResult(entry TibiaDataCacheEntry, requestHandler func(string) (interface{}, error)) tibiaDataCoalescingResult {
+	jsonData, err := requestHandler(entry.Content)
+	if err != nil {
+		return tibiaDataCoalescingResult{Err: err}
+	}
+
+	return tibiaDataCoalescingResult{
+		Entry:    entry,
+		JSONData: tibiaDataStaleResponse(jsonData, entry.Created),
+	}
+}
+
+// tibiaDataHistoryHandler func - returns the snapshots of the kind and key within the range of the request
+func tibiaDataHistoryHandler(c *gin.Context, kind, key string) {
+	if TibiaDataHistory == nil {
+		TibiaDataErrorHandler(c, validation.ErrorHistoryDisabled, http.StatusNotFound)
 		return
 	}
 
-	jsonData, err := requestHandler(BoxContentHTML)
+	now := time.Now()
+	from, err := tibiaDataTimeFromQuery(c, "from", time.Time{})
 	if err != nil {
-		TibiaDataErrorHandler(c, err, 0)
+		TibiaDataErrorHandler(c, err, http.StatusBadRequest)
+		return
+	}
+	to, err := tibiaDataTimeFromQuery(c, "to", now)
+	if err != nil {
+		TibiaDataErrorHandler(c, err, http.StatusBadRequest)
+		return
+	}
+	if to.Before(from) {
+		TibiaDataErrorHandler(c, validation.ErrorHistoryRangeInvalid, http.StatusBadRequest)
 		return
 	}
 
-	// return jsonData
-	TibiaDataAPIHandleResponse(c, handlerName, jsonData)
+	limit := TibiaDataHistoryDefaultLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			TibiaDataErrorHandler(c, validation.ErrorStringCanNotBeConvertedToInt, http.StatusBadRequest)
+			return
+		}
+		limit = min(max(limit, 1), TibiaDataHistoryMaxLimit)
+	}
+
+	jsonData, err := TibiaHistoryImpl(kind, key, from, to, limit)
+	if err != nil {
+		TibiaDataErrorHandler(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	TibiaDataAPIHandleResponse(c, "TibiaHistory", jsonData)
+}
+
+// tibiaDataTimeFromQuery func - reads an optional time of the request (RFC3339 or YYYY-MM-DD)
+func tibiaDataTimeFromQuery(c *gin.Context, key string, defaultVal time.Time) (time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return defaultVal, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, validation.ErrorHistoryRangeInvalid
+}
+
+// tibiaDataRangeFromQuery func - reads an optional from/to range of the request
+// an error other than validation.ErrorStringCanNotBeConvertedToInt means the range itself is invalid
+func tibiaDataRangeFromQuery(c *gin.Context, fromKey, toKey string) (int, int, error) {
+	var from, to int
+
+	if fromStr := c.Query(fromKey); fromStr != "" {
+		value, err := strconv.Atoi(fromStr)
+		if err != nil {
+			return 0, 0, validation.ErrorStringCanNotBeConvertedToInt
+		}
+		from = value
+	}
+
+	if toStr := c.Query(toKey); toStr != "" {
+		value, err := strconv.Atoi(toStr)
+		if err != nil {
+			return 0, 0, validation.ErrorStringCanNotBeConvertedToInt
+		}
+		to = value
+	}
+
+	if from < 0 || to < 0 || (to > 0 && from > to) {
+		return 0, 0, fmt.Errorf("invalid range %d-%d", from, to)
+	}
+
+	return from, to, nil
 }
 
 // TibiaDataAPIHandleResponse func - handling of responses..
@@ -1195,52 +2445,57 @@ func TibiaDataUserAgentGenerator(version int) string {
 	return useragent
 }
 
+// tibiaDataProxyProbe func - requests the front page of tibia.com through the proxy
+func tibiaDataProxyProbe(proxy *TibiaDataProxy) (int, error) {
+	res, err := TibiaDataClient.R().Get(proxy.URL)
+	return res.StatusCode(), err
+}
+
 // TibiaDataHTMLDataCollector func
 func TibiaDataHTMLDataCollector(TibiaDataRequest TibiaDataRequestStruct) (string, error) {
-	// Setting up resty client
-	client := resty.New()
-
-	// Set Debug if enabled by TibiaDataDebug var
-	if TibiaDataDebug {
-		client.SetDebug(true)
-		client.EnableTrace()
+	// Not requesting tibia.com while it is failing
+	if err := TibiaDataBreaker.Allow(time.Now()); err != nil {
+		return "", err
 	}
 
-	// Set client timeout  and retry
-	client.SetTimeout(5 * time.Second)
-	client.SetRetryCount(2)
-
-	// Set headers for all requests
-	client.SetHeaders(map[string]string{
-		"Content-Type": "application/json",
-		"User-Agent":   TibiaDataUserAgent,
-	})
+	// Waiting for our turn, so tibia.com does not throttle us
+	if err := TibiaDataLimiter.Wait(TibiaDataRequest.Priority); err != nil {
+		TibiaDataBreaker.Cancel()
+		log.Printf("[warning] TibiaDataHTMLDataCollector: request to %s rejected by the rate limiter!", TibiaDataRequest.URL)
+		return "", err
+	}
 
-	// Enabling Content length value for all request
-	client.SetContentLength(true)
+	// Picking the proxy of the request if env TIBIADATA_PROXY set
+	proxy, err := TibiaDataProxies.Pick(time.Now())
+	if err != nil {
+		TibiaDataBreaker.Cancel()
+		log.Printf("[warning] TibiaDataHTMLDataCollector: request to %s rejected, since all proxies are ejected!", TibiaDataRequest.URL)
+		return "", err
+	}
 
-	// Disable redirection of client (so we skip parsing maintenance page)
-	client.SetRedirectPolicy(resty.NoRedirectPolicy())
+	client := TibiaDataClient
 
-	// Replace domain with proxy if env TIBIADATA_PROXY set
-	if TibiaDataProxyDomain != "" {
-		TibiaDataRequest.URL = strings.ReplaceAll(TibiaDataRequest.URL, "https://www.tibia.com/", TibiaDataProxyDomain)
+	// Replace domain with proxy
+	if proxy != nil {
+		TibiaDataRequest.URL = strings.ReplaceAll(TibiaDataRequest.URL, "https://www.tibia.com/", proxy.URL)
 	}
 
 	// defining values for request
 	var (
 		res        *resty.Response
-		err        error
 		LogMessage string
 	)
 
 	switch TibiaDataRequest.Method {
 	case resty.MethodPost:
 		res, err = client.R().
+			SetContext(tibiaDataPriorityContext(TibiaDataRequest.Priority)).
 			SetFormData(TibiaDataRequest.FormData).
 			Post(TibiaDataRequest.URL)
 	default:
-		res, err = client.R().Get(TibiaDataRequest.URL)
+		res, err = client.R().
+			SetContext(tibiaDataPriorityContext(TibiaDataRequest.Priority)).
+			Get(TibiaDataRequest.URL)
 	}
 
 	if TibiaDataDebug {
@@ -1248,6 +2503,22 @@ func TibiaDataHTMLDataCollector(TibiaDataRequest TibiaDataRequestStruct) (string
 		TibiaDataRequestTraceLogger(res, err)
 	}
 
+	// recording which proxy served the request and whether it failed
+	TibiaDataProxies.Done(proxy, time.Now(), TibiaDataRequest.URL, res.StatusCode(), err)
+
+	// throttling, server errors and timeouts count as failures of tibia.com,
+	// unless other proxies are still healthy, so one failing proxy is only ejected
+	switch {
+	case err == nil && res.StatusCode() != http.StatusForbidden && res.StatusCode() < http.StatusInternalServerError:
+		TibiaDataBreaker.Success()
+	case proxy != nil && TibiaDataProxies.Alternative(proxy, time.Now()):
+		TibiaDataBreaker.Cancel()
+	case err != nil:
+		TibiaDataBreaker.Failure(time.Now(), err)
+	default:
+		TibiaDataBreaker.Failure(time.Now(), fmt.Errorf("tibia.com responded with status %d", res.StatusCode()))
+	}
+
 	if err != nil {
 		log.Printf("[error] TibiaDataHTMLDataCollector (Status: %s, URL: %s) in resp1: %s", res.Status(), res.Request.URL, err)
 		return "", err
@@ -1315,8 +2586,9 @@ func rootz(c *gin.Context) {
 }
 
 // healthz is a k8s liveness probe
+// the state of tibia.com is shown, but does not affect the status
 func healthz(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"status": http.StatusText(http.StatusOK)})
+	c.JSON(http.StatusOK, gin.H{"status": http.StatusText(http.StatusOK), "upstream": TibiaDataBreaker.Stats()})
 }
 
 // readyz is a k8s readiness probe
@@ -1325,5 +2597,5 @@ func readyz(c *gin.Context) {
 		c.JSON(http.StatusServiceUnavailable, gin.H{"error": http.StatusText(http.StatusServiceUnavailable)})
 		return
 	}
-	TibiaDataAPIHandleResponse(c, "readyz", gin.H{"status": http.StatusText(http.StatusOK)})
+	TibiaDataAPIHandleResponse(c, "readyz", gin.H{"status": http.StatusText(http.StatusOK), "upstream": TibiaDataBreaker.Stats()})
 }
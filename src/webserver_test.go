@@ -22,7 +22,7 @@ func TestFakeToUpCodeCoverage(t *testing.T) {
 
 	// adding support for proxy for tests
 	if isEnvExist("TIBIADATA_PROXY") {
-		TibiaDataProxyDomain = "https://" + getEnv("TIBIADATA_PROXY", "www.tibia.com") + "/"
+		TibiaDataProxies = NewTibiaDataProxyPool(TibiaDataProxyURLs(getEnv("TIBIADATA_PROXY", "www.tibia.com"), "https"), TibiaDataProxyStrategy, TibiaDataProxyEjectThreshold, TibiaDataProxyEjectDuration, TibiaDataProxyMaxEjectDuration)
 	}
 
 	w := httptest.NewRecorder()